@@ -29,13 +29,13 @@ type (
 	Descriptor                    C.struct_AVCodecDescriptor
 	Parser                        C.struct_AVCodecParser
 	ParserContext                 C.struct_AVCodecParserContext
-	Frame                         C.struct_AVFrame
-	MediaType                     C.enum_AVMediaType
+	Frame                         = avutil.Frame
+	MediaType                     = avutil.MediaType
 	Packet                        C.struct_AVPacket
 	BitStreamFilter               C.struct_AVBitStreamFilter
 	BitStreamFilterContext        C.struct_AVBitStreamFilterContext
-	Rational                      C.struct_AVRational
-	Class                         C.struct_AVClass
+	Rational                      = avutil.Rational
+	Class                         = avutil.Class
 	AvHWAccel                     C.struct_AVHWAccel
 	AvPacketSideData              C.struct_AVPacketSideData
 	AvPanScan                     C.struct_AVPanScan
@@ -48,14 +48,14 @@ type (
 	AvAudioServiceType            C.enum_AVAudioServiceType
 	AvChromaLocation              C.enum_AVChromaLocation
 	CodecId                       C.enum_AVCodecID
-	AvColorPrimaries              C.enum_AVColorPrimaries
-	AvColorRange                  C.enum_AVColorRange
-	AvColorSpace                  C.enum_AVColorSpace
-	AvColorTransferCharacteristic C.enum_AVColorTransferCharacteristic
+	AvColorPrimaries              = avutil.AvColorPrimaries
+	AvColorRange                  = avutil.AvColorRange
+	AvColorSpace                  = avutil.AvColorSpace
+	AvColorTransferCharacteristic = avutil.AvColorTransferCharacteristic
 	AvDiscard                     C.enum_AVDiscard
 	AvFieldOrder                  C.enum_AVFieldOrder
 	AvPacketSideDataType          C.enum_AVPacketSideDataType
-	AvSampleFormat                C.enum_AVSampleFormat
+	AvSampleFormat                = avutil.AvSampleFormat
 )
 
 const (
@@ -225,17 +225,17 @@ func AvcodecLicense() string {
 
 //Get the Class for Context.
 func AvcodecGetClass() *Class {
-	return (*Class)(C.avcodec_get_class())
+	return (*Class)(unsafe.Pointer(C.avcodec_get_class()))
 }
 
 //Get the Class for Frame.
 func AvcodecGetFrameClass() *Class {
-	return (*Class)(C.avcodec_get_frame_class())
+	return (*Class)(unsafe.Pointer(C.avcodec_get_frame_class()))
 }
 
 //Get the Class for AvSubtitleRect.
 func AvcodecGetSubtitleRectClass() *Class {
-	return (*Class)(C.avcodec_get_subtitle_rect_class())
+	return (*Class)(unsafe.Pointer(C.avcodec_get_subtitle_rect_class()))
 }
 
 //Free all allocated data in the given subtitle struct.
@@ -301,7 +301,7 @@ func AvcodecString(b string, bs int, ctxt *Context, e int) {
 
 //Fill Frame audio data and linesize pointers.
 func AvcodecFillAudioFrame(f *Frame, c int, s AvSampleFormat, b *uint8, bs, a int) int {
-	return int(C.avcodec_fill_audio_frame((*C.struct_AVFrame)(f), C.int(c), (C.enum_AVSampleFormat)(s), (*C.uint8_t)(b), C.int(bs), C.int(a)))
+	return int(C.avcodec_fill_audio_frame((*C.struct_AVFrame)(unsafe.Pointer(f)), C.int(c), (C.enum_AVSampleFormat)(s), (*C.uint8_t)(b), C.int(bs), C.int(a)))
 }
 
 //Return codec bits per sample.
@@ -71,6 +71,13 @@ func (ctxt *Context) AvCodecSetChromaIntraMatrix(t *uint16) {
 func AvcodecFreeContext(ctxt *Context) {
 	var ptr *C.struct_AVCodecContext = (*C.struct_AVCodecContext)(unsafe.Pointer(ctxt))
 	C.avcodec_free_context(&ptr)
+	avutil.UnregisterLogContext(unsafe.Pointer(ctxt))
+}
+
+//Route messages logged by the codec context, and by the contexts it is the parent of, to h.
+//If h is nil, messages go back to the global log handler.
+func (ctxt *Context) SetLogHandler(h avutil.LogHandler) {
+	avutil.RegisterLogContext(unsafe.Pointer(ctxt), h)
 }
 
 //Set the fields of the given Context to default values corresponding to the given codec (defaults may be codec-dependent).
@@ -95,7 +102,7 @@ func (ctxt *Context) AvcodecClose() int {
 
 //The default callback for Context.get_buffer2().
 func (ctxt *Context) AvcodecDefaultGetBuffer2(f *Frame, l int) int {
-	return int(C.avcodec_default_get_buffer2((*C.struct_AVCodecContext)(unsafe.Pointer(ctxt)), (*C.struct_AVFrame)(f), C.int(l)))
+	return int(C.avcodec_default_get_buffer2((*C.struct_AVCodecContext)(unsafe.Pointer(ctxt)), (*C.struct_AVFrame)(unsafe.Pointer(f)), C.int(l)))
 }
 
 //Modify width and height values so that they will result in a memory buffer that is acceptable for the codec if you do not use any horizontal padding.
@@ -110,7 +117,7 @@ func (ctxt *Context) AvcodecAlignDimensions2(w, h *int, l int) {
 
 //Decode the audio frame of size avpkt->size from avpkt->data into frame.
 // func (ctxt *Context) AvcodecDecodeAudio4(f *Frame, g *int, a *Packet) int {
-// 	return int(C.avcodec_decode_audio4((*C.struct_AVCodecContext)(unsafe.Pointer(ctxt)), (*C.struct_AVFrame)(f), (*C.int)(unsafe.Pointer(g)), (*C.struct_AVPacket)(a)))
+// 	return int(C.avcodec_decode_audio4((*C.struct_AVCodecContext)(unsafe.Pointer(ctxt)), (*C.struct_AVFrame)(unsafe.Pointer(f)), (*C.int)(unsafe.Pointer(g)), (*C.struct_AVPacket)(a)))
 // }
 
 //Decode the video frame of size avpkt->size from avpkt->data into picture.
@@ -145,12 +152,12 @@ func (ctxt *Context) AvcodecDecodeSubtitle2(s *AvSubtitle, g *int, a *Packet) in
 
 //Encode a frame of audio.
 // func (ctxt *Context) AvcodecEncodeAudio2(p *Packet, f *Frame, gp *int) int {
-// 	return int(C.avcodec_encode_audio2((*C.struct_AVCodecContext)(unsafe.Pointer(ctxt)), (*C.struct_AVPacket)(p), (*C.struct_AVFrame)(f), (*C.int)(unsafe.Pointer(gp))))
+// 	return int(C.avcodec_encode_audio2((*C.struct_AVCodecContext)(unsafe.Pointer(ctxt)), (*C.struct_AVPacket)(p), (*C.struct_AVFrame)(unsafe.Pointer(f)), (*C.int)(unsafe.Pointer(gp))))
 // }
 
 //Encode a frame of video
 // func (ctxt *Context) AvcodecEncodeVideo2(p *Packet, f *Frame, gp *int) int {
-// 	return int(C.avcodec_encode_video2((*C.struct_AVCodecContext)(unsafe.Pointer(ctxt)), (*C.struct_AVPacket)(p), (*C.struct_AVFrame)(f), (*C.int)(unsafe.Pointer(gp))))
+// 	return int(C.avcodec_encode_video2((*C.struct_AVCodecContext)(unsafe.Pointer(ctxt)), (*C.struct_AVPacket)(p), (*C.struct_AVFrame)(unsafe.Pointer(f)), (*C.int)(unsafe.Pointer(gp))))
 // }
 
 func (ctxt *Context) AvcodecEncodeSubtitle(b *uint8, bs int, s *AvSubtitle) int {
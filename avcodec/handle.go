@@ -0,0 +1,62 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avcodec
+
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//PacketHandle owns a Packet allocated by NewPacket().
+type PacketHandle struct {
+	*avutil.Handle
+}
+
+//Allocate a Packet owned by the returned handle, which must be closed.
+func NewPacket() (*PacketHandle, error) {
+	p := AvPacketAlloc()
+	if p == nil {
+		return nil, avutil.NewError(avutil.AVERROR_ENOMEM)
+	}
+	return &PacketHandle{avutil.NewHandle("avcodec.Packet", unsafe.Pointer(p), func(p unsafe.Pointer) {
+		AvPacketFree((*Packet)(p))
+	})}, nil
+}
+
+//Return the packet owned by the handle, or nil once it is closed or released.
+func (p *PacketHandle) Packet() *Packet {
+	return (*Packet)(p.Pointer())
+}
+
+//Give up the ownership of the packet, which must then be freed with AvPacketFree().
+func (p *PacketHandle) Release() *Packet {
+	return (*Packet)(p.Handle.Release())
+}
+
+//ContextHandle owns a codec Context allocated by NewContext().
+type ContextHandle struct {
+	*avutil.Handle
+}
+
+//Allocate a codec Context for c, which may be nil, owned by the returned handle, which must be closed.
+func NewContext(c *Codec) (*ContextHandle, error) {
+	ctx := c.AvcodecAllocContext3()
+	if ctx == nil {
+		return nil, avutil.NewError(avutil.AVERROR_ENOMEM)
+	}
+	return &ContextHandle{avutil.NewHandle("avcodec.Context", unsafe.Pointer(ctx), func(p unsafe.Pointer) {
+		AvcodecFreeContext((*Context)(p))
+	})}, nil
+}
+
+//Return the codec context owned by the handle, or nil once it is closed or released.
+func (c *ContextHandle) Context() *Context {
+	return (*Context)(c.Pointer())
+}
+
+//Give up the ownership of the codec context, which must then be freed with AvcodecFreeContext().
+func (c *ContextHandle) Release() *Context {
+	return (*Context)(c.Handle.Release())
+}
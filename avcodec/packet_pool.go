@@ -0,0 +1,92 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avcodec
+
+import (
+	"sync"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//PacketPool hands out reusable packets. Packets are unreferenced when they are put back,
+//so that the pool never holds on to the data of a packet.
+type PacketPool struct {
+	//counters is accessed atomically and must stay the first field to be 64-bit aligned on 32-bit platforms.
+	counters avutil.PoolCounters
+	mu       sync.Mutex
+	packets  []*Packet
+	capacity int
+}
+
+//Return a pool keeping at most capacity idle packets, all of them allocated upfront.
+func NewPacketPool(capacity int) (*PacketPool, error) {
+	p := &PacketPool{capacity: capacity, packets: make([]*Packet, 0, capacity)}
+	for i := 0; i < capacity; i++ {
+		pkt := AvPacketAlloc()
+		if pkt == nil {
+			p.Free()
+			return nil, avutil.NewError(avutil.AVERROR_ENOMEM)
+		}
+		p.packets = append(p.packets, pkt)
+	}
+	return p, nil
+}
+
+//Return an idle packet, or a newly allocated one if the pool is empty.
+func (p *PacketPool) Get() (*Packet, error) {
+	p.mu.Lock()
+	var pkt *Packet
+	if n := len(p.packets); n > 0 {
+		pkt = p.packets[n-1]
+		p.packets = p.packets[:n-1]
+	}
+	p.mu.Unlock()
+
+	if pkt != nil {
+		p.counters.Hit()
+		return pkt, nil
+	}
+	p.counters.Miss()
+	if pkt = AvPacketAlloc(); pkt == nil {
+		return nil, avutil.NewError(avutil.AVERROR_ENOMEM)
+	}
+	return pkt, nil
+}
+
+//Unreference pkt and give it back to the pool, or free it if the pool is full.
+func (p *PacketPool) Put(pkt *Packet) {
+	if pkt == nil {
+		return
+	}
+	pkt.AvPacketUnref()
+	p.mu.Lock()
+	if len(p.packets) < p.capacity {
+		p.packets = append(p.packets, pkt)
+		pkt = nil
+	}
+	p.mu.Unlock()
+	if pkt != nil {
+		p.counters.Discard()
+		AvPacketFree(pkt)
+	}
+}
+
+//Return the metrics of the pool.
+func (p *PacketPool) Stats() avutil.PoolStats {
+	p.mu.Lock()
+	idle := len(p.packets)
+	p.mu.Unlock()
+	return p.counters.Stats(idle)
+}
+
+//Free the idle packets. Packets put back afterwards are still kept, up to the capacity of the pool.
+func (p *PacketPool) Free() {
+	p.mu.Lock()
+	packets := p.packets
+	p.packets = nil
+	p.mu.Unlock()
+	for _, pkt := range packets {
+		AvPacketFree(pkt)
+	}
+}
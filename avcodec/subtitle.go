@@ -0,0 +1,323 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avcodec
+
+//#cgo pkg-config: libavcodec libavutil
+//#include <stdlib.h>
+//#include <string.h>
+//#include <libavcodec/avcodec.h>
+//#include <libavutil/mem.h>
+import "C"
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"time"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//SubtitleType is the type of a subtitle rectangle.
+type SubtitleType int
+
+const (
+	SUBTITLE_NONE   SubtitleType = C.SUBTITLE_NONE
+	SUBTITLE_BITMAP SubtitleType = C.SUBTITLE_BITMAP
+	SUBTITLE_TEXT   SubtitleType = C.SUBTITLE_TEXT
+	SUBTITLE_ASS    SubtitleType = C.SUBTITLE_ASS
+)
+
+const (
+	AV_SUBTITLE_FLAG_FORCED = C.AV_SUBTITLE_FLAG_FORCED
+)
+
+const (
+	//Size of the buffer subtitles are encoded into, as used by ffmpeg.
+	subtitleMaxSize = 1 << 20
+	//Maximum number of palette entries, see AVPALETTE_COUNT.
+	paletteCount = 256
+	maxRects     = 1 << 20
+)
+
+//SubtitleRect is a Go copy of an AvSubtitleRect.
+type SubtitleRect struct {
+	Type SubtitleType
+	//Position of the bitmap in the video frame.
+	X int
+	Y int
+	//Bitmap of a SUBTITLE_BITMAP rectangle, with colors in non premultiplied ARGB.
+	Bitmap *image.Paletted
+	//Plain text of a SUBTITLE_TEXT rectangle.
+	Text string
+	//ASS dialogue line of a SUBTITLE_ASS rectangle.
+	Ass   string
+	Flags int
+}
+
+//Subtitle is a Go copy of an AvSubtitle.
+type Subtitle struct {
+	Format int
+	//Display times, relative to Pts.
+	StartDisplayTime time.Duration
+	EndDisplayTime   time.Duration
+	//Presentation timestamp in AV_TIME_BASE units.
+	Pts   int64
+	Rects []SubtitleRect
+}
+
+//Allocate an empty AvSubtitle, which must be freed with Free().
+func NewAvSubtitle() *AvSubtitle {
+	return (*AvSubtitle)(C.av_mallocz(C.size_t(unsafe.Sizeof(C.struct_AVSubtitle{}))))
+}
+
+//Free the rectangles of an AvSubtitle allocated by NewAvSubtitle() or Subtitle.AvSubtitle(), and the AvSubtitle itself.
+func (s *AvSubtitle) Free() {
+	if s == nil {
+		return
+	}
+	C.avsubtitle_free((*C.struct_AVSubtitle)(s))
+	C.av_free(unsafe.Pointer(s))
+}
+
+func (s *AvSubtitle) rectSlice() []*C.struct_AVSubtitleRect {
+	if s.num_rects == 0 || s.rects == nil {
+		return nil
+	}
+	n := int(s.num_rects)
+	return (*[maxRects]*C.struct_AVSubtitleRect)(unsafe.Pointer(s.rects))[:n:n]
+}
+
+//Return a Go copy of the subtitle.
+func (s *AvSubtitle) Subtitle() *Subtitle {
+	sub := &Subtitle{
+		Format:           int(s.format),
+		StartDisplayTime: time.Duration(s.start_display_time) * time.Millisecond,
+		EndDisplayTime:   time.Duration(s.end_display_time) * time.Millisecond,
+		Pts:              int64(s.pts),
+	}
+	for _, r := range s.rectSlice() {
+		sub.Rects = append(sub.Rects, subtitleRectFromC(r))
+	}
+	return sub
+}
+
+func subtitleRectFromC(r *C.struct_AVSubtitleRect) SubtitleRect {
+	sr := SubtitleRect{
+		Type:  SubtitleType(r._type),
+		X:     int(r.x),
+		Y:     int(r.y),
+		Flags: int(r.flags),
+	}
+	if r.text != nil {
+		sr.Text = C.GoString(r.text)
+	}
+	if r.ass != nil {
+		sr.Ass = C.GoString(r.ass)
+	}
+	w, h := int(r.w), int(r.h)
+	if r.data[0] == nil || w <= 0 || h <= 0 {
+		return sr
+	}
+
+	var palette color.Palette
+	if r.data[1] != nil && r.nb_colors > 0 {
+		n := int(r.nb_colors)
+		if n > paletteCount {
+			n = paletteCount
+		}
+		entries := (*[paletteCount]C.uint32_t)(unsafe.Pointer(r.data[1]))[:n:n]
+		palette = make(color.Palette, n)
+		for i, c := range entries {
+			palette[i] = color.NRGBA{R: uint8(c >> 16), G: uint8(c >> 8), B: uint8(c), A: uint8(c >> 24)}
+		}
+	}
+	sr.Bitmap = image.NewPaletted(image.Rect(0, 0, w, h), palette)
+	linesize := int(r.linesize[0])
+	for y := 0; y < h; y++ {
+		row := unsafe.Pointer(uintptr(unsafe.Pointer(r.data[0])) + uintptr(y*linesize))
+		copy(sr.Bitmap.Pix[y*sr.Bitmap.Stride:y*sr.Bitmap.Stride+w], C.GoBytes(row, C.int(w)))
+	}
+	return sr
+}
+
+//Write the bitmap of the rectangle as a PNG image.
+func (r SubtitleRect) EncodePNG(w io.Writer) error {
+	if r.Bitmap == nil {
+		return errors.New("avcodec: subtitle rectangle has no bitmap")
+	}
+	return png.Encode(w, r.Bitmap)
+}
+
+//Allocate an AvSubtitle holding a copy of the subtitle, which must be freed with Free().
+func (s *Subtitle) AvSubtitle() (*AvSubtitle, error) {
+	cs := NewAvSubtitle()
+	if cs == nil {
+		return nil, errors.New("avcodec: allocating subtitle failed")
+	}
+	cs.format = C.uint16_t(s.Format)
+	cs.start_display_time = C.uint32_t(s.StartDisplayTime / time.Millisecond)
+	cs.end_display_time = C.uint32_t(s.EndDisplayTime / time.Millisecond)
+	cs.pts = C.int64_t(s.Pts)
+	if len(s.Rects) == 0 {
+		return cs, nil
+	}
+
+	cs.rects = (**C.struct_AVSubtitleRect)(C.av_calloc(C.size_t(len(s.Rects)), C.size_t(unsafe.Sizeof(uintptr(0)))))
+	if cs.rects == nil {
+		cs.Free()
+		return nil, errors.New("avcodec: allocating subtitle rectangles failed")
+	}
+	rects := (*[maxRects]*C.struct_AVSubtitleRect)(unsafe.Pointer(cs.rects))[:len(s.Rects):len(s.Rects)]
+	for i := range s.Rects {
+		r := (*C.struct_AVSubtitleRect)(C.av_mallocz(C.size_t(unsafe.Sizeof(C.struct_AVSubtitleRect{}))))
+		if r == nil {
+			cs.Free()
+			return nil, errors.New("avcodec: allocating subtitle rectangle failed")
+		}
+		rects[i] = r
+		cs.num_rects++
+		if err := s.Rects[i].fill(r); err != nil {
+			cs.Free()
+			return nil, err
+		}
+	}
+	return cs, nil
+}
+
+//Copy the rectangle into r, whose memory is released by avsubtitle_free().
+func (sr SubtitleRect) fill(r *C.struct_AVSubtitleRect) error {
+	r._type = C.enum_AVSubtitleType(sr.Type)
+	r.x = C.int(sr.X)
+	r.y = C.int(sr.Y)
+	r.flags = C.int(sr.Flags)
+	if sr.Text != "" {
+		if r.text = avStrdup(sr.Text); r.text == nil {
+			return errors.New("avcodec: allocating subtitle text failed")
+		}
+	}
+	if sr.Ass != "" {
+		if r.ass = avStrdup(sr.Ass); r.ass == nil {
+			return errors.New("avcodec: allocating subtitle text failed")
+		}
+	}
+	if sr.Bitmap == nil {
+		return nil
+	}
+
+	b := sr.Bitmap.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if len(sr.Bitmap.Palette) > paletteCount {
+		return errors.New("avcodec: subtitle palette has more than 256 colors")
+	}
+	r.w = C.int(w)
+	r.h = C.int(h)
+	r.nb_colors = C.int(len(sr.Bitmap.Palette))
+	r.linesize[0] = C.int(w)
+	if r.data[0] = (*C.uint8_t)(C.av_mallocz(C.size_t(w * h))); r.data[0] == nil {
+		return errors.New("avcodec: allocating subtitle bitmap failed")
+	}
+	if r.data[1] = (*C.uint8_t)(C.av_mallocz(C.size_t(paletteCount * 4))); r.data[1] == nil {
+		return errors.New("avcodec: allocating subtitle palette failed")
+	}
+
+	pix := (*[1 << 30]byte)(unsafe.Pointer(r.data[0]))[: w*h : w*h]
+	for y := 0; y < h; y++ {
+		o := sr.Bitmap.PixOffset(b.Min.X, b.Min.Y+y)
+		copy(pix[y*w:(y+1)*w], sr.Bitmap.Pix[o:o+w])
+	}
+	entries := (*[paletteCount]C.uint32_t)(unsafe.Pointer(r.data[1]))[:]
+	for i, c := range sr.Bitmap.Palette {
+		n := color.NRGBAModel.Convert(c).(color.NRGBA)
+		entries[i] = C.uint32_t(n.A)<<24 | C.uint32_t(n.R)<<16 | C.uint32_t(n.G)<<8 | C.uint32_t(n.B)
+	}
+	return nil
+}
+
+//Duplicate s into memory allocated with av_malloc().
+func avStrdup(s string) *C.char {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return C.av_strdup(cs)
+}
+
+//Decode the subtitle held by pkt. It returns nil if the packet does not complete a subtitle.
+func (ctxt *Context) DecodeSubtitle(pkt *Packet) (*Subtitle, error) {
+	cs := NewAvSubtitle()
+	if cs == nil {
+		return nil, errors.New("avcodec: allocating subtitle failed")
+	}
+	defer cs.Free()
+	var got C.int
+	ret := C.avcodec_decode_subtitle2((*C.struct_AVCodecContext)(unsafe.Pointer(ctxt)), (*C.struct_AVSubtitle)(cs), &got, (*C.struct_AVPacket)(pkt))
+	if err := avutil.NewError(int(ret)); err != nil {
+		return nil, err
+	}
+	if got == 0 {
+		return nil, nil
+	}
+	return cs.Subtitle(), nil
+}
+
+//Encode s and return the encoded data.
+func (ctxt *Context) EncodeSubtitle(s *Subtitle) ([]byte, error) {
+	cs, err := s.AvSubtitle()
+	if err != nil {
+		return nil, err
+	}
+	defer cs.Free()
+	buf := C.av_malloc(subtitleMaxSize)
+	if buf == nil {
+		return nil, errors.New("avcodec: allocating subtitle buffer failed")
+	}
+	defer C.av_free(buf)
+	n := ctxt.AvcodecEncodeSubtitle((*uint8)(buf), subtitleMaxSize, cs)
+	if err := avutil.NewError(n); err != nil {
+		return nil, err
+	}
+	return C.GoBytes(buf, C.int(n)), nil
+}
+
+//Decode the subtitle held by pkt with dec and encode it with enc, e.g. to convert SRT to WebVTT.
+//It returns nil if the packet does not complete a subtitle.
+//Text based encoders need the subtitle header of dec, which must be copied with enc.SetSubtitleHeader(dec.SubtitleHeader()) before enc is opened.
+//Bitmap subtitles, such as DVB ones, can be converted to images with SubtitleRect.EncodePNG() instead.
+func TranscodeSubtitle(dec, enc *Context, pkt *Packet) ([]byte, *Subtitle, error) {
+	s, err := dec.DecodeSubtitle(pkt)
+	if err != nil || s == nil {
+		return nil, nil, err
+	}
+	b, err := enc.EncodeSubtitle(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, s, nil
+}
+
+//Return the header of text subtitles, e.g. the ASS header set by decoders.
+func (ctxt *Context) SubtitleHeader() []byte {
+	if ctxt.subtitle_header == nil || ctxt.subtitle_header_size <= 0 {
+		return nil
+	}
+	return C.GoBytes(unsafe.Pointer(ctxt.subtitle_header), ctxt.subtitle_header_size)
+}
+
+//Set the header of text subtitles. Must be called before the encoder is opened.
+func (ctxt *Context) SetSubtitleHeader(h []byte) error {
+	C.av_freep(unsafe.Pointer(&ctxt.subtitle_header))
+	ctxt.subtitle_header_size = 0
+	if len(h) == 0 {
+		return nil
+	}
+	p := C.av_mallocz(C.size_t(len(h) + 1))
+	if p == nil {
+		return errors.New("avcodec: allocating subtitle header failed")
+	}
+	C.memcpy(p, unsafe.Pointer(&h[0]), C.size_t(len(h)))
+	ctxt.subtitle_header = (*C.uint8_t)(p)
+	ctxt.subtitle_header_size = C.int(len(h))
+	return nil
+}
@@ -15,6 +15,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/asticode/goav/avformat"
 	"github.com/asticode/goav/avutil"
 )
 
@@ -23,9 +24,9 @@ type (
 	AvDeviceCapabilitiesQuery C.struct_AVDeviceCapabilitiesQuery
 	AvDeviceInfo              C.struct_AVDeviceInfo
 	AvDeviceInfoList          C.struct_AVDeviceInfoList
-	InputFormat               C.struct_AVInputFormat
-	OutputFormat              C.struct_AVOutputFormat
-	AvFormatContext           C.struct_AVFormatContext
+	InputFormat               = avformat.InputFormat
+	OutputFormat              = avformat.OutputFormat
+	AvFormatContext           = avformat.Context
 	AvAppToDevMessageType     C.enum_AVAppToDevMessageType
 	AvDevToAppMessageType     C.enum_AVDevToAppMessageType
 )
@@ -52,27 +53,27 @@ func AvdeviceRegisterAll() {
 
 //Send control message from application to device.
 func AvdeviceAppToDevControlMessage(s *AvFormatContext, m AvAppToDevMessageType, da int, d uintptr) int {
-	return int(C.avdevice_app_to_dev_control_message((*C.struct_AVFormatContext)(s), (C.enum_AVAppToDevMessageType)(m), unsafe.Pointer(&da), C.size_t(d)))
+	return int(C.avdevice_app_to_dev_control_message((*C.struct_AVFormatContext)(unsafe.Pointer(s)), (C.enum_AVAppToDevMessageType)(m), unsafe.Pointer(&da), C.size_t(d)))
 }
 
 //Send control message from device to application.
 func AvdeviceDevToAppControlMessage(fcxt *AvFormatContext, m AvDevToAppMessageType, da int, d uintptr) int {
-	return int(C.avdevice_dev_to_app_control_message((*C.struct_AVFormatContext)(fcxt), (C.enum_AVDevToAppMessageType)(m), unsafe.Pointer(&da), C.size_t(d)))
+	return int(C.avdevice_dev_to_app_control_message((*C.struct_AVFormatContext)(unsafe.Pointer(fcxt)), (C.enum_AVDevToAppMessageType)(m), unsafe.Pointer(&da), C.size_t(d)))
 }
 
 //Initialize capabilities probing API based on AvOption API.
 func AvdeviceCapabilitiesCreate(c **AvDeviceCapabilitiesQuery, s *AvFormatContext, d **avutil.Dictionary) int {
-	return int(C.avdevice_capabilities_create((**C.struct_AVDeviceCapabilitiesQuery)(unsafe.Pointer(c)), (*C.struct_AVFormatContext)(s), (**C.struct_AVDictionary)(unsafe.Pointer(d))))
+	return int(C.avdevice_capabilities_create((**C.struct_AVDeviceCapabilitiesQuery)(unsafe.Pointer(c)), (*C.struct_AVFormatContext)(unsafe.Pointer(s)), (**C.struct_AVDictionary)(unsafe.Pointer(d))))
 }
 
 //Free resources created by avdevice_capabilities_create()
 func AvdeviceCapabilitiesFree(c **AvDeviceCapabilitiesQuery, s *AvFormatContext) {
-	C.avdevice_capabilities_free((**C.struct_AVDeviceCapabilitiesQuery)(unsafe.Pointer(c)), (*C.struct_AVFormatContext)(s))
+	C.avdevice_capabilities_free((**C.struct_AVDeviceCapabilitiesQuery)(unsafe.Pointer(c)), (*C.struct_AVFormatContext)(unsafe.Pointer(s)))
 }
 
 //List devices.
 func AvdeviceListDevices(s *AvFormatContext, d **AvDeviceInfoList) int {
-	return int(C.avdevice_list_devices((*C.struct_AVFormatContext)(s), (**C.struct_AVDeviceInfoList)(unsafe.Pointer(d))))
+	return int(C.avdevice_list_devices((*C.struct_AVFormatContext)(unsafe.Pointer(s)), (**C.struct_AVDeviceInfoList)(unsafe.Pointer(d))))
 }
 
 //Convenient function to free result of avdeviceListDevices().
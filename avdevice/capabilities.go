@@ -0,0 +1,236 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avdevice
+
+/*
+	#cgo pkg-config: libavdevice libavformat libavutil
+	#include <stdlib.h>
+	#include <libavdevice/avdevice.h>
+	#include <libavutil/opt.h>
+*/
+import "C"
+import (
+	"errors"
+	"strconv"
+	"unsafe"
+
+	"github.com/asticode/goav/avformat"
+	"github.com/asticode/goav/avutil"
+)
+
+const maxOptionRanges = 1 << 16
+
+//Range is an inclusive range of values supported by a device.
+type Range struct {
+	Min float64
+	Max float64
+}
+
+//SizeRange is a range of image sizes supported by a device.
+type SizeRange struct {
+	MinWidth  int
+	MaxWidth  int
+	MinHeight int
+	MaxHeight int
+}
+
+//Capabilities queries the capabilities of a device opened in an avformat.Context.
+//Constraints set with Set() narrow down the values returned by the other methods,
+//e.g. setting "pixel_format" before querying frame sizes.
+type Capabilities struct {
+	q *C.struct_AVDeviceCapabilitiesQuery
+	s *avformat.Context
+}
+
+//Initialize a capabilities query for the device opened in s. It must be freed with Free().
+//opts, which may be nil, is replaced with a dictionary of the options that were not found.
+func NewCapabilities(s *avformat.Context, opts **avutil.Dictionary) (*Capabilities, error) {
+	c := &Capabilities{s: s}
+	ret := C.avdevice_capabilities_create(&c.q, (*C.struct_AVFormatContext)(unsafe.Pointer(s)), (**C.struct_AVDictionary)(unsafe.Pointer(opts)))
+	if err := avutil.NewError(int(ret)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+//Set the constraint named key, e.g. "pixel_format" or "sample_rate", to value.
+func (c *Capabilities) Set(key, value string) error {
+	ck := C.CString(key)
+	defer C.free(unsafe.Pointer(ck))
+	cv := C.CString(value)
+	defer C.free(unsafe.Pointer(cv))
+	return avutil.NewError(int(C.av_opt_set(unsafe.Pointer(c.q), ck, cv, 0)))
+}
+
+//Return the supported pixel formats.
+func (c *Capabilities) PixelFormats() ([]avutil.PixelFormat, error) {
+	vs, err := c.values("pixel_format", int(C.AV_PIX_FMT_NB))
+	if err != nil {
+		return nil, err
+	}
+	fs := make([]avutil.PixelFormat, 0, len(vs))
+	for _, v := range vs {
+		fs = append(fs, avutil.PixelFormat(v))
+	}
+	return fs, nil
+}
+
+//Return the supported sample formats.
+func (c *Capabilities) SampleFormats() ([]avutil.AvSampleFormat, error) {
+	vs, err := c.values("sample_format", int(C.AV_SAMPLE_FMT_NB))
+	if err != nil {
+		return nil, err
+	}
+	fs := make([]avutil.AvSampleFormat, 0, len(vs))
+	for _, v := range vs {
+		fs = append(fs, avutil.AvSampleFormat(v))
+	}
+	return fs, nil
+}
+
+//Return the ranges of the supported codec ids.
+func (c *Capabilities) Codecs() ([]Range, error) {
+	return c.scalarRanges("codec")
+}
+
+//Return the supported channel layouts.
+func (c *Capabilities) ChannelLayouts() ([]uint64, error) {
+	rs, err := c.ranges("channel_layout")
+	if err != nil {
+		return nil, err
+	}
+	ls := make([]uint64, 0, len(rs))
+	for _, r := range rs {
+		ls = append(ls, uint64(r[0].Min))
+	}
+	return ls, nil
+}
+
+//Return the supported sample rates.
+func (c *Capabilities) SampleRates() ([]Range, error) {
+	return c.scalarRanges("sample_rate")
+}
+
+//Return the supported numbers of channels.
+func (c *Capabilities) Channels() ([]Range, error) {
+	return c.scalarRanges("channels")
+}
+
+//Return the supported frame rates, in frames per second.
+func (c *Capabilities) FrameRates() ([]Range, error) {
+	return c.scalarRanges("fps")
+}
+
+//Return the supported frame sizes.
+func (c *Capabilities) FrameSizes() ([]SizeRange, error) {
+	return c.sizeRanges("frame_size")
+}
+
+//Return the supported window sizes.
+func (c *Capabilities) WindowSizes() ([]SizeRange, error) {
+	return c.sizeRanges("window_size")
+}
+
+//Free the query.
+func (c *Capabilities) Free() {
+	if c.q != nil {
+		C.avdevice_capabilities_free(&c.q, (*C.struct_AVFormatContext)(unsafe.Pointer(c.s)))
+	}
+}
+
+//Return the ranges of the option named key, indexed as [range][component].
+func (c *Capabilities) ranges(key string) ([][]Range, error) {
+	ck := C.CString(key)
+	defer C.free(unsafe.Pointer(ck))
+	var rs *C.AVOptionRanges
+	ret := C.av_opt_query_ranges(&rs, unsafe.Pointer(c.q), ck, C.AV_OPT_MULTI_COMPONENT_RANGE)
+	if err := avutil.NewError(int(ret)); err != nil {
+		return nil, err
+	}
+	defer C.av_opt_freep_ranges(&rs)
+
+	n, nc := int(rs.nb_ranges), int(rs.nb_components)
+	if n <= 0 || nc <= 0 {
+		return nil, nil
+	}
+	// Ranges are stored component after component.
+	crs := (*[maxOptionRanges]*C.AVOptionRange)(unsafe.Pointer(rs._range))[: n*nc : n*nc]
+	out := make([][]Range, n)
+	for i := range out {
+		out[i] = make([]Range, nc)
+		for j := range out[i] {
+			r := crs[j*n+i]
+			out[i][j] = Range{Min: float64(r.value_min), Max: float64(r.value_max)}
+		}
+	}
+	return out, nil
+}
+
+func (c *Capabilities) scalarRanges(key string) ([]Range, error) {
+	rs, err := c.ranges(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Range, 0, len(rs))
+	for _, r := range rs {
+		out = append(out, r[0])
+	}
+	return out, nil
+}
+
+func (c *Capabilities) sizeRanges(key string) ([]SizeRange, error) {
+	rs, err := c.ranges(key)
+	if err != nil {
+		return nil, err
+	}
+	return toSizeRanges(key, rs)
+}
+
+//Convert the ranges of an image size option, whose components are the pixel count, the width and the height.
+func toSizeRanges(key string, rs [][]Range) ([]SizeRange, error) {
+	out := make([]SizeRange, 0, len(rs))
+	for _, r := range rs {
+		if len(r) < 3 {
+			return nil, errors.New("avdevice: " + key + " ranges have " + strconv.Itoa(len(r)) + " components instead of pixel count, width and height")
+		}
+		out = append(out, SizeRange{
+			MinWidth:  int(r[1].Min),
+			MaxWidth:  int(r[1].Max),
+			MinHeight: int(r[2].Min),
+			MaxHeight: int(r[2].Max),
+		})
+	}
+	return out, nil
+}
+
+//Return the integer values of the ranges of the option named key, which are lower than limit.
+func (c *Capabilities) values(key string, limit int) ([]int, error) {
+	rs, err := c.scalarRanges(key)
+	if err != nil {
+		return nil, err
+	}
+	return expandRanges(rs, limit), nil
+}
+
+//Return every integer value of rs between 0 and limit, excluded, without duplicates.
+func expandRanges(rs []Range, limit int) []int {
+	seen := make(map[int]bool)
+	var vs []int
+	for _, r := range rs {
+		min, max := r.Min, r.Max
+		if min < 0 {
+			min = 0
+		}
+		if max > float64(limit-1) {
+			max = float64(limit - 1)
+		}
+		for v := int(min); v <= int(max); v++ {
+			if !seen[v] {
+				seen[v] = true
+				vs = append(vs, v)
+			}
+		}
+	}
+	return vs
+}
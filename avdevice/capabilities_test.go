@@ -0,0 +1,99 @@
+package avdevice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/asticode/goav/avformat"
+	"github.com/asticode/goav/avutil"
+)
+
+func openLavfi(t *testing.T, graph string) *avformat.ContextHandle {
+	AvdeviceRegisterAll()
+	ifmt := avformat.AvFindInputFormat("lavfi")
+	if ifmt == nil {
+		t.Skip("lavfi input device is not available")
+	}
+	s, err := avformat.OpenInput(graph, ifmt, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestNewCapabilitiesLavfi(t *testing.T) {
+	s := openLavfi(t, "sine=frequency=440:sample_rate=48000")
+	defer s.Close()
+
+	var opts *avutil.Dictionary
+	avutil.AvDictSet(&opts, "unknown_option", "1", 0)
+	defer avutil.AvDictFree(&opts)
+
+	// lavfi does not implement capabilities queries: the options must be left untouched.
+	c, err := NewCapabilities(s.Context(), &opts)
+	if err == nil {
+		c.Free()
+		t.Fatal("expected lavfi not to support capabilities queries")
+	}
+	if err != avutil.NewError(avutil.AVERROR_ENOSYS) {
+		t.Fatalf("error is %v, expected ENOSYS", err)
+	}
+	if opts == nil || opts.Map()["unknown_option"] != "1" {
+		t.Fatalf("options are %v, expected them to be left untouched", opts)
+	}
+}
+
+func TestListDevicesLavfi(t *testing.T) {
+	AvdeviceRegisterAll()
+	if avformat.AvFindInputFormat("lavfi") == nil {
+		t.Skip("lavfi input device is not available")
+	}
+	ds, def, err := ListDevices("lavfi", nil)
+	if err == nil {
+		t.Fatalf("listed %v, expected lavfi not to support listing devices", ds)
+	}
+	if def != -1 {
+		t.Fatalf("default device is %d, expected -1", def)
+	}
+}
+
+func TestExpandRanges(t *testing.T) {
+	for _, c := range []struct {
+		rs       []Range
+		limit    int
+		expected []int
+	}{
+		{nil, 10, nil},
+		{[]Range{{Min: 2, Max: 2}, {Min: 5, Max: 7}}, 10, []int{2, 5, 6, 7}},
+		{[]Range{{Min: 1, Max: 3}, {Min: 2, Max: 4}}, 10, []int{1, 2, 3, 4}},
+		// Ranges such as [INT_MIN, INT_MAX] are clamped to the valid values instead of being expanded.
+		{[]Range{{Min: -2147483648, Max: 2147483647}}, 4, []int{0, 1, 2, 3}},
+		{[]Range{{Min: 12, Max: 20}}, 10, nil},
+	} {
+		if vs := expandRanges(c.rs, c.limit); !reflect.DeepEqual(vs, c.expected) {
+			t.Errorf("expandRanges(%v, %d) is %v, expected %v", c.rs, c.limit, vs, c.expected)
+		}
+	}
+}
+
+func TestToSizeRanges(t *testing.T) {
+	rs := [][]Range{
+		{{Min: 640 * 480, Max: 1920 * 1080}, {Min: 640, Max: 1920}, {Min: 480, Max: 1080}},
+		{{Min: 320 * 240, Max: 320 * 240}, {Min: 320, Max: 320}, {Min: 240, Max: 240}},
+	}
+	srs, err := toSizeRanges("frame_size", rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []SizeRange{
+		{MinWidth: 640, MaxWidth: 1920, MinHeight: 480, MaxHeight: 1080},
+		{MinWidth: 320, MaxWidth: 320, MinHeight: 240, MaxHeight: 240},
+	}
+	if !reflect.DeepEqual(srs, expected) {
+		t.Fatalf("size ranges are %v, expected %v", srs, expected)
+	}
+
+	if _, err := toSizeRanges("frame_size", [][]Range{{{Min: 0, Max: 1}}}); err == nil {
+		t.Fatal("expected ranges with a single component to be rejected")
+	}
+}
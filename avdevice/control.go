@@ -0,0 +1,214 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avdevice
+
+/*
+	#cgo pkg-config: libavdevice libavformat libavutil
+	#include <libavdevice/avdevice.h>
+	#include <libavformat/avformat.h>
+
+	extern int goavControlMessage(AVFormatContext *s, int type, void *data, size_t size);
+
+	static int goavControlMessageCb(struct AVFormatContext *s, int type, void *data, size_t size)
+	{
+		return goavControlMessage(s, type, data, size);
+	}
+
+	static void goavSetControlMessageCb(AVFormatContext *s, int enable)
+	{
+		s->control_message_cb = enable ? goavControlMessageCb : NULL;
+	}
+*/
+import "C"
+import (
+	"image"
+	"sync"
+	"unsafe"
+
+	"github.com/asticode/goav/avformat"
+	"github.com/asticode/goav/avutil"
+)
+
+const (
+	AV_APP_TO_DEV_NONE           AvAppToDevMessageType = C.AV_APP_TO_DEV_NONE
+	AV_APP_TO_DEV_WINDOW_SIZE    AvAppToDevMessageType = C.AV_APP_TO_DEV_WINDOW_SIZE
+	AV_APP_TO_DEV_WINDOW_REPAINT AvAppToDevMessageType = C.AV_APP_TO_DEV_WINDOW_REPAINT
+	AV_APP_TO_DEV_PAUSE          AvAppToDevMessageType = C.AV_APP_TO_DEV_PAUSE
+	AV_APP_TO_DEV_PLAY           AvAppToDevMessageType = C.AV_APP_TO_DEV_PLAY
+	AV_APP_TO_DEV_TOGGLE_PAUSE   AvAppToDevMessageType = C.AV_APP_TO_DEV_TOGGLE_PAUSE
+	AV_APP_TO_DEV_SET_VOLUME     AvAppToDevMessageType = C.AV_APP_TO_DEV_SET_VOLUME
+	AV_APP_TO_DEV_MUTE           AvAppToDevMessageType = C.AV_APP_TO_DEV_MUTE
+	AV_APP_TO_DEV_UNMUTE         AvAppToDevMessageType = C.AV_APP_TO_DEV_UNMUTE
+	AV_APP_TO_DEV_TOGGLE_MUTE    AvAppToDevMessageType = C.AV_APP_TO_DEV_TOGGLE_MUTE
+	AV_APP_TO_DEV_GET_VOLUME     AvAppToDevMessageType = C.AV_APP_TO_DEV_GET_VOLUME
+	AV_APP_TO_DEV_GET_MUTE       AvAppToDevMessageType = C.AV_APP_TO_DEV_GET_MUTE
+)
+
+const (
+	AV_DEV_TO_APP_NONE                  AvDevToAppMessageType = C.AV_DEV_TO_APP_NONE
+	AV_DEV_TO_APP_CREATE_WINDOW_BUFFER  AvDevToAppMessageType = C.AV_DEV_TO_APP_CREATE_WINDOW_BUFFER
+	AV_DEV_TO_APP_PREPARE_WINDOW_BUFFER AvDevToAppMessageType = C.AV_DEV_TO_APP_PREPARE_WINDOW_BUFFER
+	AV_DEV_TO_APP_DISPLAY_WINDOW_BUFFER AvDevToAppMessageType = C.AV_DEV_TO_APP_DISPLAY_WINDOW_BUFFER
+	AV_DEV_TO_APP_DESTROY_WINDOW_BUFFER AvDevToAppMessageType = C.AV_DEV_TO_APP_DESTROY_WINDOW_BUFFER
+	AV_DEV_TO_APP_BUFFER_OVERFLOW       AvDevToAppMessageType = C.AV_DEV_TO_APP_BUFFER_OVERFLOW
+	AV_DEV_TO_APP_BUFFER_UNDERFLOW      AvDevToAppMessageType = C.AV_DEV_TO_APP_BUFFER_UNDERFLOW
+	AV_DEV_TO_APP_BUFFER_READABLE       AvDevToAppMessageType = C.AV_DEV_TO_APP_BUFFER_READABLE
+	AV_DEV_TO_APP_BUFFER_WRITABLE       AvDevToAppMessageType = C.AV_DEV_TO_APP_BUFFER_WRITABLE
+	AV_DEV_TO_APP_MUTE_STATE_CHANGED    AvDevToAppMessageType = C.AV_DEV_TO_APP_MUTE_STATE_CHANGED
+	AV_DEV_TO_APP_VOLUME_LEVEL_CHANGED  AvDevToAppMessageType = C.AV_DEV_TO_APP_VOLUME_LEVEL_CHANGED
+)
+
+//ControlMessage is a control message sent by a device to the application.
+type ControlMessage struct {
+	Type AvDevToAppMessageType
+	//Window area of AV_DEV_TO_APP_CREATE_WINDOW_BUFFER, or nil if unspecified.
+	Rect *image.Rectangle
+	//Number of bytes of AV_DEV_TO_APP_BUFFER_READABLE and AV_DEV_TO_APP_BUFFER_WRITABLE, or -1 if unknown.
+	Bytes int64
+	//Mute state of AV_DEV_TO_APP_MUTE_STATE_CHANGED.
+	Muted bool
+	//Volume of AV_DEV_TO_APP_VOLUME_LEVEL_CHANGED, between 0 and 1.
+	Volume float64
+}
+
+//ControlMessageHandler handles the control messages sent by a device.
+//A non nil error is returned to the device, as an AVERROR code if it is an avutil.Error.
+type ControlMessageHandler func(m ControlMessage) error
+
+var controlMessageHandlers = struct {
+	sync.RWMutex
+	m map[*C.struct_AVFormatContext]ControlMessageHandler
+}{m: make(map[*C.struct_AVFormatContext]ControlMessageHandler)}
+
+func init() {
+	avformat.RegisterContextFreeHook(func(s *avformat.Context) {
+		controlMessageHandlers.Lock()
+		delete(controlMessageHandlers.m, (*C.struct_AVFormatContext)(unsafe.Pointer(s)))
+		controlMessageHandlers.Unlock()
+	})
+}
+
+//Route the control messages sent by the device opened in s to h, or stop routing them if h is nil.
+//The handler is removed when s is closed with avformat.AvformatCloseInput() or freed with AvformatFreeContext().
+func SetControlMessageHandler(s *avformat.Context, h ControlMessageHandler) {
+	cs := (*C.struct_AVFormatContext)(unsafe.Pointer(s))
+	controlMessageHandlers.Lock()
+	defer controlMessageHandlers.Unlock()
+	if h == nil {
+		delete(controlMessageHandlers.m, cs)
+		C.goavSetControlMessageCb(cs, 0)
+		return
+	}
+	controlMessageHandlers.m[cs] = h
+	C.goavSetControlMessageCb(cs, 1)
+}
+
+//export goavControlMessage
+func goavControlMessage(s *C.struct_AVFormatContext, t C.int, data unsafe.Pointer, size C.size_t) C.int {
+	controlMessageHandlers.RLock()
+	h, ok := controlMessageHandlers.m[s]
+	controlMessageHandlers.RUnlock()
+	if !ok {
+		return C.int(avutil.AVERROR_ENOSYS)
+	}
+
+	m := ControlMessage{Type: AvDevToAppMessageType(t), Bytes: -1}
+	switch m.Type {
+	case AV_DEV_TO_APP_CREATE_WINDOW_BUFFER:
+		if data != nil && uintptr(size) >= unsafe.Sizeof(C.AVDeviceRect{}) {
+			r := (*C.AVDeviceRect)(data)
+			rect := image.Rect(int(r.x), int(r.y), int(r.x+r.width), int(r.y+r.height))
+			m.Rect = &rect
+		}
+	case AV_DEV_TO_APP_BUFFER_READABLE, AV_DEV_TO_APP_BUFFER_WRITABLE:
+		if data != nil && uintptr(size) >= unsafe.Sizeof(C.int64_t(0)) {
+			m.Bytes = int64(*(*C.int64_t)(data))
+		}
+	case AV_DEV_TO_APP_MUTE_STATE_CHANGED:
+		if data != nil && uintptr(size) >= unsafe.Sizeof(C.int(0)) {
+			m.Muted = *(*C.int)(data) != 0
+		}
+	case AV_DEV_TO_APP_VOLUME_LEVEL_CHANGED:
+		if data != nil && uintptr(size) >= unsafe.Sizeof(C.double(0)) {
+			m.Volume = float64(*(*C.double)(data))
+		}
+	}
+
+	err := h(m)
+	if err == nil {
+		return 0
+	}
+	if e, ok := err.(avutil.Error); ok {
+		return C.int(e)
+	}
+	return C.int(avutil.AVERROR_EXTERNAL)
+}
+
+func sendControlMessage(s *avformat.Context, t AvAppToDevMessageType, data unsafe.Pointer, size uintptr) error {
+	return avutil.NewError(int(C.avdevice_app_to_dev_control_message((*C.struct_AVFormatContext)(unsafe.Pointer(s)), (C.enum_AVAppToDevMessageType)(t), data, C.size_t(size))))
+}
+
+func sendRectMessage(s *avformat.Context, t AvAppToDevMessageType, r image.Rectangle) error {
+	cr := C.AVDeviceRect{x: C.int(r.Min.X), y: C.int(r.Min.Y), width: C.int(r.Dx()), height: C.int(r.Dy())}
+	return sendControlMessage(s, t, unsafe.Pointer(&cr), unsafe.Sizeof(cr))
+}
+
+//Inform the device of the new size and position of its window.
+func SetWindowSize(s *avformat.Context, r image.Rectangle) error {
+	return sendRectMessage(s, AV_APP_TO_DEV_WINDOW_SIZE, r)
+}
+
+//Ask the device to repaint the area r of its window, or all of it if r is empty.
+func RepaintWindow(s *avformat.Context, r image.Rectangle) error {
+	if r.Empty() {
+		return sendControlMessage(s, AV_APP_TO_DEV_WINDOW_REPAINT, nil, 0)
+	}
+	return sendRectMessage(s, AV_APP_TO_DEV_WINDOW_REPAINT, r)
+}
+
+//Pause the device.
+func Pause(s *avformat.Context) error {
+	return sendControlMessage(s, AV_APP_TO_DEV_PAUSE, nil, 0)
+}
+
+//Resume the device.
+func Play(s *avformat.Context) error {
+	return sendControlMessage(s, AV_APP_TO_DEV_PLAY, nil, 0)
+}
+
+//Pause the device if it is playing, resume it otherwise.
+func TogglePause(s *avformat.Context) error {
+	return sendControlMessage(s, AV_APP_TO_DEV_TOGGLE_PAUSE, nil, 0)
+}
+
+//Set the volume of the device, between 0 and 1.
+func SetVolume(s *avformat.Context, v float64) error {
+	cv := C.double(v)
+	return sendControlMessage(s, AV_APP_TO_DEV_SET_VOLUME, unsafe.Pointer(&cv), unsafe.Sizeof(cv))
+}
+
+//Mute the device.
+func Mute(s *avformat.Context) error {
+	return sendControlMessage(s, AV_APP_TO_DEV_MUTE, nil, 0)
+}
+
+//Unmute the device.
+func Unmute(s *avformat.Context) error {
+	return sendControlMessage(s, AV_APP_TO_DEV_UNMUTE, nil, 0)
+}
+
+//Mute the device if it is unmuted, unmute it otherwise.
+func ToggleMute(s *avformat.Context) error {
+	return sendControlMessage(s, AV_APP_TO_DEV_TOGGLE_MUTE, nil, 0)
+}
+
+//Ask the device for its volume, which it sends back as an AV_DEV_TO_APP_VOLUME_LEVEL_CHANGED message.
+func RequestVolume(s *avformat.Context) error {
+	return sendControlMessage(s, AV_APP_TO_DEV_GET_VOLUME, nil, 0)
+}
+
+//Ask the device for its mute state, which it sends back as an AV_DEV_TO_APP_MUTE_STATE_CHANGED message.
+func RequestMuteState(s *avformat.Context) error {
+	return sendControlMessage(s, AV_APP_TO_DEV_GET_MUTE, nil, 0)
+}
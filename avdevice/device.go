@@ -0,0 +1,103 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avdevice
+
+/*
+	#cgo pkg-config: libavdevice libavformat libavutil
+	#include <stdlib.h>
+	#include <libavdevice/avdevice.h>
+	#include <libavformat/avformat.h>
+
+	// AVDeviceInfo.media_types was added in libavdevice 58.12.100.
+	static int goavDeviceInfoNbMediaTypes(AVDeviceInfo *d)
+	{
+	#if LIBAVDEVICE_VERSION_INT >= AV_VERSION_INT(58, 12, 100)
+		return d->nb_media_types;
+	#else
+		return 0;
+	#endif
+	}
+
+	static enum AVMediaType goavDeviceInfoMediaType(AVDeviceInfo *d, int i)
+	{
+	#if LIBAVDEVICE_VERSION_INT >= AV_VERSION_INT(58, 12, 100)
+		return d->media_types[i];
+	#else
+		return AVMEDIA_TYPE_UNKNOWN;
+	#endif
+	}
+*/
+import "C"
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+const maxDevices = 1 << 16
+
+//DeviceInfo describes a device listed by ListDevices().
+type DeviceInfo struct {
+	Name        string
+	Description string
+	//Media types the device provides, empty if unknown.
+	MediaTypes []avutil.MediaType
+}
+
+//List the devices of the input or output device format named formatName, e.g. "pulse" or "v4l2".
+//It returns the devices and the index of the default one, or -1 if there is none.
+func ListDevices(formatName string, opts *avutil.Dictionary) ([]DeviceInfo, int, error) {
+	cn := C.CString(formatName)
+	defer C.free(unsafe.Pointer(cn))
+
+	var l *C.struct_AVDeviceInfoList
+	var ret C.int
+	if ifmt := C.av_find_input_format(cn); ifmt != nil {
+		ret = C.avdevice_list_input_sources(ifmt, nil, (*C.struct_AVDictionary)(unsafe.Pointer(opts)), &l)
+	} else if ofmt := C.av_guess_format(cn, nil, nil); ofmt != nil {
+		ret = C.avdevice_list_output_sinks(ofmt, nil, (*C.struct_AVDictionary)(unsafe.Pointer(opts)), &l)
+	} else {
+		return nil, -1, errors.New("avdevice: unknown device format " + formatName)
+	}
+	defer C.avdevice_free_list_devices(&l)
+	if err := avutil.NewError(int(ret)); err != nil {
+		return nil, -1, err
+	}
+	if l == nil {
+		return nil, -1, nil
+	}
+	return (*AvDeviceInfoList)(l).Devices(), int(l.default_device), nil
+}
+
+//Return a Go copy of the listed devices.
+func (l *AvDeviceInfoList) Devices() []DeviceInfo {
+	if l.nb_devices <= 0 || l.devices == nil {
+		return nil
+	}
+	n := int(l.nb_devices)
+	ds := make([]DeviceInfo, 0, n)
+	for _, d := range (*[maxDevices]*C.struct_AVDeviceInfo)(unsafe.Pointer(l.devices))[:n:n] {
+		ds = append(ds, (*AvDeviceInfo)(d).DeviceInfo())
+	}
+	return ds
+}
+
+//Return the index of the default device, or -1 if there is none.
+func (l *AvDeviceInfoList) DefaultDevice() int {
+	return int(l.default_device)
+}
+
+//Return a Go copy of the device info.
+func (d *AvDeviceInfo) DeviceInfo() DeviceInfo {
+	cd := (*C.struct_AVDeviceInfo)(d)
+	i := DeviceInfo{
+		Name:        C.GoString(cd.device_name),
+		Description: C.GoString(cd.device_description),
+	}
+	for j := 0; j < int(C.goavDeviceInfoNbMediaTypes(cd)); j++ {
+		i.MediaTypes = append(i.MediaTypes, avutil.MediaType(C.goavDeviceInfoMediaType(cd, C.int(j))))
+	}
+	return i
+}
@@ -8,23 +8,24 @@ package avdevice
 	#include <libavdevice/avdevice.h>
 */
 import "C"
+import "unsafe"
 
 //Audio input devices iterator.
-func (d *InputFormat) AvInputAudioDeviceNext() *InputFormat {
-	return (*InputFormat)(C.av_input_audio_device_next((*C.struct_AVInputFormat)(d)))
+func AvInputAudioDeviceNext(d *InputFormat) *InputFormat {
+	return (*InputFormat)(unsafe.Pointer(C.av_input_audio_device_next((*C.struct_AVInputFormat)(unsafe.Pointer(d)))))
 }
 
 //Video input devices iterator.
-func (d *InputFormat) AvInputVideoDeviceNext() *InputFormat {
-	return (*InputFormat)(C.av_input_video_device_next((*C.struct_AVInputFormat)(d)))
+func AvInputVideoDeviceNext(d *InputFormat) *InputFormat {
+	return (*InputFormat)(unsafe.Pointer(C.av_input_video_device_next((*C.struct_AVInputFormat)(unsafe.Pointer(d)))))
 }
 
 //Audio output devices iterator.
-func (d *OutputFormat) AvOutputAudioDeviceNext() *OutputFormat {
-	return (*OutputFormat)(C.av_output_audio_device_next((*C.struct_AVOutputFormat)(d)))
+func AvOutputAudioDeviceNext(d *OutputFormat) *OutputFormat {
+	return (*OutputFormat)(unsafe.Pointer(C.av_output_audio_device_next((*C.struct_AVOutputFormat)(unsafe.Pointer(d)))))
 }
 
 //Video output devices iterator.
-func (d *OutputFormat) AvOutputVideoDeviceNext() *OutputFormat {
-	return (*OutputFormat)(C.av_output_video_device_next((*C.struct_AVOutputFormat)(d)))
+func AvOutputVideoDeviceNext(d *OutputFormat) *OutputFormat {
+	return (*OutputFormat)(unsafe.Pointer(C.av_output_video_device_next((*C.struct_AVOutputFormat)(unsafe.Pointer(d)))))
 }
@@ -24,14 +24,21 @@ type (
 	Graph     C.struct_AVFilterGraph
 	Input     C.struct_AVFilterInOut
 	Pad       C.struct_AVFilterPad
-	Class     C.struct_AVClass
-	MediaType C.enum_AVMediaType
+	Class     = avutil.Class
+	MediaType = avutil.MediaType
 )
 
 const (
 	MAX_ARRAY_SIZE = 1<<29 - 1
 )
 
+//Filter contexts do not reference their graph as log parent, so messages they log are attributed to their graph explicitly.
+func init() {
+	avutil.RegisterLogParentFunc("AVFilter", func(ctx unsafe.Pointer) unsafe.Pointer {
+		return unsafe.Pointer((*C.struct_AVFilterContext)(ctx).graph)
+	})
+}
+
 //Return the LIBAvFILTER_VERSION_INT constant.
 func AvfilterVersion() uint {
 	return uint(C.avfilter_version())
@@ -101,6 +108,18 @@ func AvfilterProcessCommand(f *Context, cmd, arg, res string, l, fl int) int {
 	return int(C.avfilter_process_command((*C.struct_AVFilterContext)(f), cc, ca, cr, C.int(l), C.int(fl)))
 }
 
+//Make the filter instance process a command and return its response.
+func (ctx *Context) ProcessCommand(cmd, arg string) (string, error) {
+	cc := C.CString(cmd)
+	defer C.free(unsafe.Pointer(cc))
+	ca := C.CString(arg)
+	defer C.free(unsafe.Pointer(ca))
+	cr := (*C.char)(C.calloc(MAX_COMMAND_RESPONSE_LEN, 1))
+	defer C.free(unsafe.Pointer(cr))
+	ret := int(C.avfilter_process_command((*C.struct_AVFilterContext)(ctx), cc, ca, cr, MAX_COMMAND_RESPONSE_LEN, 0))
+	return C.GoString(cr), avutil.NewError(ret)
+}
+
 //Initialize the filter system.
 func AvfilterRegisterAll() {
 	panic("deprecated")
@@ -132,6 +151,24 @@ func (ctx *Context) NbOutputs() uint {
 	return uint(ctx.nb_outputs)
 }
 
+func (ctx *Context) NbThreads() int {
+	return int(ctx.nb_threads)
+}
+
+//Set the maximum number of threads used by this filter instance, 0 meaning no limit other than the graph's.
+func (ctx *Context) SetNbThreads(n int) {
+	ctx.nb_threads = C.int(n)
+}
+
+func (ctx *Context) ThreadType() int {
+	return int(ctx.thread_type)
+}
+
+//Set the type of multithreading allowed for this filter instance.
+func (ctx *Context) SetThreadType(t int) {
+	ctx.thread_type = C.int(t)
+}
+
 func (ctx *Context) Inputs() []*Link {
 	if ctx.NbInputs() == 0 {
 		return nil
@@ -163,7 +200,7 @@ func AvfilterInsertFilter(l *Link, f *Context, fsi, fdi uint) int {
 
 //avfilter_get_class
 func AvfilterGetClass() *Class {
-	return (*Class)(C.avfilter_get_class())
+	return (*Class)(unsafe.Pointer(C.avfilter_get_class()))
 }
 
 //Allocate a single Input entry.
@@ -0,0 +1,79 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avfilter
+
+/*
+	#cgo pkg-config: libavfilter
+	#include <libavfilter/avfilter.h>
+	#include <libavfilter/buffersink.h>
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//Get the media type of the buffersink output.
+func (ctx *Context) AvBuffersinkGetType() MediaType {
+	return (MediaType)(C.av_buffersink_get_type((*C.struct_AVFilterContext)(ctx)))
+}
+
+//Get the time base of the buffersink output.
+func (ctx *Context) AvBuffersinkGetTimeBase() avutil.Rational {
+	r := C.av_buffersink_get_time_base((*C.struct_AVFilterContext)(ctx))
+	return *(*avutil.Rational)(unsafe.Pointer(&r))
+}
+
+//Get the pixel or sample format of the buffersink output.
+func (ctx *Context) AvBuffersinkGetFormat() int {
+	return int(C.av_buffersink_get_format((*C.struct_AVFilterContext)(ctx)))
+}
+
+//Get the frame rate of the buffersink output.
+func (ctx *Context) AvBuffersinkGetFrameRate() avutil.Rational {
+	r := C.av_buffersink_get_frame_rate((*C.struct_AVFilterContext)(ctx))
+	return *(*avutil.Rational)(unsafe.Pointer(&r))
+}
+
+//Get the width of the buffersink output.
+func (ctx *Context) AvBuffersinkGetW() int {
+	return int(C.av_buffersink_get_w((*C.struct_AVFilterContext)(ctx)))
+}
+
+//Get the height of the buffersink output.
+func (ctx *Context) AvBuffersinkGetH() int {
+	return int(C.av_buffersink_get_h((*C.struct_AVFilterContext)(ctx)))
+}
+
+//Get the sample aspect ratio of the buffersink output.
+func (ctx *Context) AvBuffersinkGetSampleAspectRatio() avutil.Rational {
+	r := C.av_buffersink_get_sample_aspect_ratio((*C.struct_AVFilterContext)(ctx))
+	return *(*avutil.Rational)(unsafe.Pointer(&r))
+}
+
+//Get the number of channels of the buffersink output.
+func (ctx *Context) AvBuffersinkGetChannels() int {
+	return int(C.av_buffersink_get_channels((*C.struct_AVFilterContext)(ctx)))
+}
+
+//Get the channel layout of the buffersink output.
+func (ctx *Context) AvBuffersinkGetChannelLayout() uint64 {
+	return uint64(C.av_buffersink_get_channel_layout((*C.struct_AVFilterContext)(ctx)))
+}
+
+//Get the sample rate of the buffersink output.
+func (ctx *Context) AvBuffersinkGetSampleRate() int {
+	return int(C.av_buffersink_get_sample_rate((*C.struct_AVFilterContext)(ctx)))
+}
+
+//Get the hardware frames context of the buffersink output.
+func (ctx *Context) AvBuffersinkGetHwFramesCtx() *avutil.AvBufferRef {
+	return (*avutil.AvBufferRef)(unsafe.Pointer(C.av_buffersink_get_hw_frames_ctx((*C.struct_AVFilterContext)(ctx))))
+}
+
+//Set the frame size for an audio buffer sink.
+func (ctx *Context) AvBuffersinkSetFrameSize(s uint) {
+	C.av_buffersink_set_frame_size((*C.struct_AVFilterContext)(ctx), C.uint(s))
+}
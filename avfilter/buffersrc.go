@@ -0,0 +1,128 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avfilter
+
+/*
+	#cgo pkg-config: libavfilter
+	#include <libavfilter/avfilter.h>
+	#include <libavfilter/buffersrc.h>
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+type BuffersrcParameters C.struct_AVBufferSrcParameters
+
+//Allocate a new BuffersrcParameters instance. It should be freed by the caller with AvFree().
+func AvBuffersrcParametersAlloc() *BuffersrcParameters {
+	return (*BuffersrcParameters)(C.av_buffersrc_parameters_alloc())
+}
+
+//Free a BuffersrcParameters instance allocated with AvBuffersrcParametersAlloc().
+func (p *BuffersrcParameters) AvFree() {
+	avutil.AvFree(unsafe.Pointer(p))
+}
+
+//Initialize the buffersrc or abuffersrc filter with the provided parameters.
+func (ctx *Context) AvBuffersrcParametersSet(p *BuffersrcParameters) int {
+	return int(C.av_buffersrc_parameters_set((*C.struct_AVFilterContext)(ctx), (*C.struct_AVBufferSrcParameters)(p)))
+}
+
+//Get the number of failed requests.
+func (ctx *Context) AvBuffersrcGetNbFailedRequests() uint {
+	return uint(C.av_buffersrc_get_nb_failed_requests((*C.struct_AVFilterContext)(ctx)))
+}
+
+//Return the pixel format of a video buffersrc, or the sample format of an audio one.
+func (p *BuffersrcParameters) Format() int {
+	return int(p.format)
+}
+
+//Set the pixel format of a video buffersrc, or the sample format of an audio one.
+func (p *BuffersrcParameters) SetFormat(f int) {
+	p.format = C.int(f)
+}
+
+//Return the time base of the frames that will be sent to the buffersrc.
+func (p *BuffersrcParameters) TimeBase() avutil.Rational {
+	return *(*avutil.Rational)(unsafe.Pointer(&p.time_base))
+}
+
+//Set the time base of the frames that will be sent to the buffersrc.
+func (p *BuffersrcParameters) SetTimeBase(r avutil.Rational) {
+	p.time_base = *((*C.struct_AVRational)(unsafe.Pointer(&r)))
+}
+
+//Return the width of the video frames.
+func (p *BuffersrcParameters) Width() int {
+	return int(p.width)
+}
+
+//Set the width of the video frames.
+func (p *BuffersrcParameters) SetWidth(w int) {
+	p.width = C.int(w)
+}
+
+//Return the height of the video frames.
+func (p *BuffersrcParameters) Height() int {
+	return int(p.height)
+}
+
+//Set the height of the video frames.
+func (p *BuffersrcParameters) SetHeight(h int) {
+	p.height = C.int(h)
+}
+
+//Return the sample aspect ratio of the video frames.
+func (p *BuffersrcParameters) SampleAspectRatio() avutil.Rational {
+	return *(*avutil.Rational)(unsafe.Pointer(&p.sample_aspect_ratio))
+}
+
+//Set the sample aspect ratio of the video frames.
+func (p *BuffersrcParameters) SetSampleAspectRatio(r avutil.Rational) {
+	p.sample_aspect_ratio = *((*C.struct_AVRational)(unsafe.Pointer(&r)))
+}
+
+//Return the frame rate of the video stream, for information only.
+func (p *BuffersrcParameters) FrameRate() avutil.Rational {
+	return *(*avutil.Rational)(unsafe.Pointer(&p.frame_rate))
+}
+
+//Set the frame rate of the video stream, for information only.
+func (p *BuffersrcParameters) SetFrameRate(r avutil.Rational) {
+	p.frame_rate = *((*C.struct_AVRational)(unsafe.Pointer(&r)))
+}
+
+//Return the hardware frames context of hardware frames, or nil.
+func (p *BuffersrcParameters) HwFramesCtx() *avutil.AvBufferRef {
+	return (*avutil.AvBufferRef)(unsafe.Pointer(p.hw_frames_ctx))
+}
+
+//Set the hardware frames context. The buffersrc takes its own reference when AvBuffersrcParametersSet() is called.
+func (p *BuffersrcParameters) SetHwFramesCtx(b *avutil.AvBufferRef) {
+	p.hw_frames_ctx = (*C.struct_AVBufferRef)(unsafe.Pointer(b))
+}
+
+//Return the sample rate of the audio frames.
+func (p *BuffersrcParameters) SampleRate() int {
+	return int(p.sample_rate)
+}
+
+//Set the sample rate of the audio frames.
+func (p *BuffersrcParameters) SetSampleRate(r int) {
+	p.sample_rate = C.int(r)
+}
+
+//Return the channel layout of the audio frames.
+func (p *BuffersrcParameters) ChannelLayout() uint64 {
+	return uint64(p.channel_layout)
+}
+
+//Set the channel layout of the audio frames.
+func (p *BuffersrcParameters) SetChannelLayout(l uint64) {
+	p.channel_layout = C.uint64_t(l)
+}
@@ -6,9 +6,78 @@ package avfilter
 /*
 	#cgo pkg-config: libavfilter
 	#include <libavfilter/avfilter.h>
+	#include <libavutil/opt.h>
+
+static const AVFilter *goavFilterIterate(uintptr_t *opaque)
+{
+	void *o = (void *)*opaque;
+	const AVFilter *f = av_filter_iterate(&o);
+	*opaque = (uintptr_t)o;
+	return f;
+}
+
+static const AVOption *goavFilterOptNext(const AVFilter *f, const AVOption *prev)
+{
+	return av_opt_next(&f->priv_class, prev);
+}
+
+static int64_t goavOptDefaultI64(const AVOption *o)
+{
+	return o->default_val.i64;
+}
+
+static double goavOptDefaultDbl(const AVOption *o)
+{
+	return o->default_val.dbl;
+}
+
+static const char *goavOptDefaultStr(const AVOption *o)
+{
+	return o->default_val.str;
+}
 */
 import "C"
-import "unsafe"
+import (
+	"strconv"
+	"unsafe"
+)
+
+const (
+	AVFILTER_FLAG_DYNAMIC_INPUTS            = C.AVFILTER_FLAG_DYNAMIC_INPUTS
+	AVFILTER_FLAG_DYNAMIC_OUTPUTS           = C.AVFILTER_FLAG_DYNAMIC_OUTPUTS
+	AVFILTER_FLAG_SLICE_THREADS             = C.AVFILTER_FLAG_SLICE_THREADS
+	AVFILTER_FLAG_SUPPORT_TIMELINE_GENERIC  = C.AVFILTER_FLAG_SUPPORT_TIMELINE_GENERIC
+	AVFILTER_FLAG_SUPPORT_TIMELINE_INTERNAL = C.AVFILTER_FLAG_SUPPORT_TIMELINE_INTERNAL
+	AVFILTER_FLAG_SUPPORT_TIMELINE          = C.AVFILTER_FLAG_SUPPORT_TIMELINE
+)
+
+//FilterPad describes an input or output pad of a filter.
+type FilterPad struct {
+	Name string
+	Type MediaType
+}
+
+//FilterOption describes a private option of a filter.
+type FilterOption struct {
+	Name  string
+	Help  string
+	Type  int
+	Min   float64
+	Max   float64
+	Flags int
+	Unit  string
+	//Default value, formatted as a number for numeric types, empty if the option has none.
+	Default string
+	//Named values of the option, i.e. the AV_OPT_TYPE_CONST options of its Unit.
+	Constants []FilterOptionConstant
+}
+
+//FilterOptionConstant is a named value of a filter option.
+type FilterOptionConstant struct {
+	Name  string
+	Help  string
+	Value int64
+}
 
 //Get a filter definition matching the given name.
 func AvfilterGetByName(n string) *Filter {
@@ -30,3 +99,126 @@ func (f *Filter) AvfilterNext() *Filter {
 	return nil
 	//return (*Filter)(C.avfilter_next((*C.struct_AVFilter)(f)))
 }
+
+//Iterate over all registered filters.
+func AvFilterIterate(opaque *uintptr) *Filter {
+	return (*Filter)(C.goavFilterIterate((*C.uintptr_t)(unsafe.Pointer(opaque))))
+}
+
+//Return all registered filters.
+func Filters() []*Filter {
+	var fs []*Filter
+	var opaque uintptr
+	for f := AvFilterIterate(&opaque); f != nil; f = AvFilterIterate(&opaque) {
+		fs = append(fs, f)
+	}
+	return fs
+}
+
+func (f *Filter) Name() string {
+	return C.GoString(f.name)
+}
+
+func (f *Filter) Description() string {
+	return C.GoString(f.description)
+}
+
+func (f *Filter) Flags() int {
+	return int(f.flags)
+}
+
+//Return whether the number of inputs is dynamic, i.e. not described by Inputs().
+func (f *Filter) HasDynamicInputs() bool {
+	return f.flags&AVFILTER_FLAG_DYNAMIC_INPUTS != 0
+}
+
+//Return whether the number of outputs is dynamic, i.e. not described by Outputs().
+func (f *Filter) HasDynamicOutputs() bool {
+	return f.flags&AVFILTER_FLAG_DYNAMIC_OUTPUTS != 0
+}
+
+//Return whether the filter supports the timeline "enable" option.
+func (f *Filter) SupportsTimeline() bool {
+	return f.flags&AVFILTER_FLAG_SUPPORT_TIMELINE != 0
+}
+
+//Return whether the filter supports multithreading by splitting frames into slices.
+func (f *Filter) SupportsSliceThreads() bool {
+	return f.flags&AVFILTER_FLAG_SLICE_THREADS != 0
+}
+
+func (f *Filter) Inputs() []FilterPad {
+	return filterPads((*Pad)(unsafe.Pointer(f.inputs)))
+}
+
+func (f *Filter) Outputs() []FilterPad {
+	return filterPads((*Pad)(unsafe.Pointer(f.outputs)))
+}
+
+func filterPads(p *Pad) []FilterPad {
+	if p == nil {
+		return nil
+	}
+	n := AvfilterPadCount(p)
+	ps := make([]FilterPad, 0, n)
+	for i := 0; i < n; i++ {
+		ps = append(ps, FilterPad{
+			Name: AvfilterPadGetName(p, i),
+			Type: AvfilterPadGetType(p, i),
+		})
+	}
+	return ps
+}
+
+//Return the private options of the filter.
+func (f *Filter) Options() []FilterOption {
+	if f.priv_class == nil {
+		return nil
+	}
+	var os []FilterOption
+	consts := make(map[string][]FilterOptionConstant)
+	for o := C.goavFilterOptNext((*C.struct_AVFilter)(f), nil); o != nil; o = C.goavFilterOptNext((*C.struct_AVFilter)(f), o) {
+		if o._type == C.AV_OPT_TYPE_CONST {
+			unit := C.GoString(o.unit)
+			consts[unit] = append(consts[unit], FilterOptionConstant{
+				Name:  C.GoString(o.name),
+				Help:  C.GoString(o.help),
+				Value: int64(C.goavOptDefaultI64(o)),
+			})
+			continue
+		}
+		os = append(os, FilterOption{
+			Name:    C.GoString(o.name),
+			Help:    C.GoString(o.help),
+			Type:    int(o._type),
+			Min:     float64(o.min),
+			Max:     float64(o.max),
+			Flags:   int(o.flags),
+			Unit:    C.GoString(o.unit),
+			Default: optionDefault(o),
+		})
+	}
+	for i := range os {
+		if os[i].Unit != "" {
+			os[i].Constants = consts[os[i].Unit]
+		}
+	}
+	return os
+}
+
+func optionDefault(o *C.struct_AVOption) string {
+	switch o._type {
+	case C.AV_OPT_TYPE_FLAGS, C.AV_OPT_TYPE_INT, C.AV_OPT_TYPE_INT64, C.AV_OPT_TYPE_BOOL,
+		C.AV_OPT_TYPE_DURATION, C.AV_OPT_TYPE_PIXEL_FMT, C.AV_OPT_TYPE_SAMPLE_FMT:
+		return strconv.FormatInt(int64(C.goavOptDefaultI64(o)), 10)
+	case C.AV_OPT_TYPE_UINT64, C.AV_OPT_TYPE_CHANNEL_LAYOUT:
+		return strconv.FormatUint(uint64(C.goavOptDefaultI64(o)), 10)
+	case C.AV_OPT_TYPE_DOUBLE, C.AV_OPT_TYPE_FLOAT, C.AV_OPT_TYPE_RATIONAL:
+		return strconv.FormatFloat(float64(C.goavOptDefaultDbl(o)), 'g', -1, 64)
+	case C.AV_OPT_TYPE_STRING, C.AV_OPT_TYPE_COLOR, C.AV_OPT_TYPE_IMAGE_SIZE, C.AV_OPT_TYPE_VIDEO_RATE, C.AV_OPT_TYPE_DICT:
+		if str := C.goavOptDefaultStr(o); str != nil {
+			return C.GoString(str)
+		}
+	}
+	return ""
+}
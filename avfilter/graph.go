@@ -11,7 +11,9 @@ package avfilter
 */
 import "C"
 import (
+	"time"
 	"unsafe"
+
 	"github.com/asticode/goav/avutil"
 )
 
@@ -19,6 +21,15 @@ const (
 	AV_BUFFERSRC_FLAG_KEEP_REF = C.AV_BUFFERSRC_FLAG_KEEP_REF
 )
 
+const (
+	AVFILTER_CMD_FLAG_ONE  = C.AVFILTER_CMD_FLAG_ONE
+	AVFILTER_CMD_FLAG_FAST = C.AVFILTER_CMD_FLAG_FAST
+)
+
+const (
+	MAX_COMMAND_RESPONSE_LEN = 4096
+)
+
 //Allocate a filter graph.
 func AvfilterGraphAlloc() *Graph {
 	return (*Graph)(C.avfilter_graph_alloc())
@@ -50,7 +61,15 @@ func (g *Graph) AvfilterGraphConfig(l *int) int {
 
 //Free a graph, destroy its links, and set *graph to NULL.
 func (g *Graph) AvfilterGraphFree() {
+	p := unsafe.Pointer(g)
 	C.avfilter_graph_free((**C.struct_AVFilterGraph)(unsafe.Pointer(&g)))
+	avutil.UnregisterLogContext(p)
+}
+
+//Route messages logged by the graph and by its filters to h.
+//If h is nil, messages go back to the global log handler.
+func (g *Graph) SetLogHandler(h avutil.LogHandler) {
+	avutil.RegisterLogContext(unsafe.Pointer(g), h)
 }
 
 //Add a graph described by a string to a graph.
@@ -98,6 +117,32 @@ func (g *Graph) AvfilterGraphQueueCommand(t, cmd, arg string, f int, ts C.double
 	return int(C.avfilter_graph_queue_command((*C.struct_AVFilterGraph)(g), ct, cc, ca, C.int(f), ts))
 }
 
+//Send a command to one or more filter instances and return their response.
+//Target is a filter instance name, a filter name or "all".
+func (g *Graph) SendCommand(target, cmd, arg string) (string, error) {
+	ct := C.CString(target)
+	defer C.free(unsafe.Pointer(ct))
+	cc := C.CString(cmd)
+	defer C.free(unsafe.Pointer(cc))
+	ca := C.CString(arg)
+	defer C.free(unsafe.Pointer(ca))
+	cr := (*C.char)(C.calloc(MAX_COMMAND_RESPONSE_LEN, 1))
+	defer C.free(unsafe.Pointer(cr))
+	ret := int(C.avfilter_graph_send_command((*C.struct_AVFilterGraph)(g), ct, cc, ca, cr, MAX_COMMAND_RESPONSE_LEN, 0))
+	return C.GoString(cr), avutil.NewError(ret)
+}
+
+//Queue a command for one or more filter instances, to be executed once the filters reach the timestamp at.
+func (g *Graph) QueueCommand(target, cmd, arg string, at time.Duration) error {
+	ct := C.CString(target)
+	defer C.free(unsafe.Pointer(ct))
+	cc := C.CString(cmd)
+	defer C.free(unsafe.Pointer(cc))
+	ca := C.CString(arg)
+	defer C.free(unsafe.Pointer(ca))
+	return avutil.NewError(int(C.avfilter_graph_queue_command((*C.struct_AVFilterGraph)(g), ct, cc, ca, 0, C.double(at.Seconds()))))
+}
+
 //Dump a graph into a human-readable string representation.
 func (g *Graph) AvfilterGraphDump(o string) string {
 	co := C.CString(o)
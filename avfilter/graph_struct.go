@@ -0,0 +1,65 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avfilter
+
+/*
+	#cgo pkg-config: libavfilter
+	#include <libavfilter/avfilter.h>
+	#include <libavutil/mem.h>
+*/
+import "C"
+import "unsafe"
+
+const (
+	AVFILTER_THREAD_SLICE = C.AVFILTER_THREAD_SLICE
+)
+
+func (g *Graph) NbThreads() int {
+	return int(g.nb_threads)
+}
+
+//Set the maximum number of threads used by filters in this graph. 0 lets libavfilter pick automatically.
+//Must be set before adding any filter to the graph.
+func (g *Graph) SetNbThreads(n int) {
+	g.nb_threads = C.int(n)
+}
+
+func (g *Graph) ThreadType() int {
+	return int(g.thread_type)
+}
+
+//Set the type of multithreading allowed for filters in this graph. 0 disables threading.
+//Must be set before adding any filter to the graph.
+func (g *Graph) SetThreadType(t int) {
+	g.thread_type = C.int(t)
+}
+
+func (g *Graph) ScaleSwsOpts() string {
+	return C.GoString(g.scale_sws_opts)
+}
+
+//Set the swscale options used for automatically inserted scale filters.
+func (g *Graph) SetScaleSwsOpts(o string) {
+	g.scale_sws_opts = graphStrdup(g.scale_sws_opts, o)
+}
+
+func (g *Graph) AresampleSwrOpts() string {
+	return C.GoString(g.aresample_swr_opts)
+}
+
+//Set the swresample options used for automatically inserted aresample filters.
+func (g *Graph) SetAresampleSwrOpts(o string) {
+	g.aresample_swr_opts = graphStrdup(g.aresample_swr_opts, o)
+}
+
+//Replace a string owned by the graph, which frees it with av_free().
+func graphStrdup(prev *C.char, s string) *C.char {
+	C.av_free(unsafe.Pointer(prev))
+	if len(s) == 0 {
+		return nil
+	}
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return C.av_strdup(cs)
+}
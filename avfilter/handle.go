@@ -0,0 +1,36 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avfilter
+
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//GraphHandle owns a Graph allocated by NewGraph().
+type GraphHandle struct {
+	*avutil.Handle
+}
+
+//Allocate a filter Graph owned by the returned handle, which must be closed.
+func NewGraph() (*GraphHandle, error) {
+	g := AvfilterGraphAlloc()
+	if g == nil {
+		return nil, avutil.NewError(avutil.AVERROR_ENOMEM)
+	}
+	return &GraphHandle{avutil.NewHandle("avfilter.Graph", unsafe.Pointer(g), func(p unsafe.Pointer) {
+		(*Graph)(p).AvfilterGraphFree()
+	})}, nil
+}
+
+//Return the graph owned by the handle, or nil once it is closed or released.
+func (g *GraphHandle) Graph() *Graph {
+	return (*Graph)(g.Pointer())
+}
+
+//Give up the ownership of the graph, which must then be freed with AvfilterGraphFree().
+func (g *GraphHandle) Release() *Graph {
+	return (*Graph)(g.Handle.Release())
+}
@@ -0,0 +1,144 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avformat
+
+//#cgo pkg-config: libavformat libavcodec
+//#include <libavformat/avformat.h>
+import "C"
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"unsafe"
+
+	"github.com/asticode/goav/avcodec"
+	"github.com/asticode/goav/avutil"
+)
+
+//Return a copy of the attached picture of the stream, e.g. the album art of an MP3 or M4A,
+//or nil if the stream does not have the AV_DISPOSITION_ATTACHED_PIC disposition.
+func (avs *Stream) AttachedPicture() []byte {
+	if !avs.Disposition().Has(AV_DISPOSITION_ATTACHED_PIC) || avs.attached_pic.data == nil || avs.attached_pic.size <= 0 {
+		return nil
+	}
+	return C.GoBytes(unsafe.Pointer(avs.attached_pic.data), avs.attached_pic.size)
+}
+
+//Return a copy of the first attached picture of the input and the codec id of its stream,
+//or nil if there is none.
+func (ctxt *Context) CoverImage() ([]byte, CodecId) {
+	for _, st := range ctxt.Streams() {
+		if b := st.AttachedPicture(); b != nil {
+			return b, st.CodecParameters().CodecId()
+		}
+	}
+	return nil, CodecId(avcodec.AV_CODEC_ID_NONE)
+}
+
+var (
+	jpegMagic = []byte{0xff, 0xd8, 0xff}
+	pngMagic  = []byte("\x89PNG\r\n\x1a\n")
+)
+
+//Time base of cover image streams, whose only packet is timestamped 0.
+var coverImageTimeBase = avutil.NewRational(1, 90000)
+
+//Add a stream holding img, a JPEG or PNG image, as the cover image of the output, e.g. the album art of an MP3 or M4A.
+//The image itself must be written with WriteAttachedPicture() once the header is written.
+func (ctxt *Context) AddCoverImageStream(img []byte) (*Stream, error) {
+	var id CodecId
+	var w, h int
+	var err error
+	switch {
+	case bytes.HasPrefix(img, jpegMagic):
+		id = CodecId(avcodec.AV_CODEC_ID_MJPEG)
+		w, h, err = jpegSize(img)
+	case bytes.HasPrefix(img, pngMagic):
+		id = CodecId(avcodec.AV_CODEC_ID_PNG)
+		w, h, err = pngSize(img)
+	default:
+		return nil, errors.New("avformat: cover image is neither a JPEG nor a PNG image")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	st := ctxt.AvformatNewStream(nil)
+	if st == nil {
+		return nil, avutil.NewError(avutil.AVERROR_ENOMEM)
+	}
+	par := st.codecpar
+	par.codec_type = C.AVMEDIA_TYPE_VIDEO
+	par.codec_id = (C.enum_AVCodecID)(id)
+	par.width = C.int(w)
+	par.height = C.int(h)
+	st.SetTimeBase(coverImageTimeBase)
+	st.SetDisposition(AV_DISPOSITION_ATTACHED_PIC)
+	return st, nil
+}
+
+//Return the size of a PNG image, read from its IHDR chunk.
+func pngSize(img []byte) (int, int, error) {
+	if len(img) < 24 || string(img[12:16]) != "IHDR" {
+		return 0, 0, errors.New("avformat: invalid PNG header")
+	}
+	return int(binary.BigEndian.Uint32(img[16:20])), int(binary.BigEndian.Uint32(img[20:24])), nil
+}
+
+//Return the size of a JPEG image, read from its start of frame segment.
+func jpegSize(img []byte) (int, int, error) {
+	for i := 2; i+1 < len(img); {
+		if img[i] != 0xff {
+			return 0, 0, errors.New("avformat: invalid JPEG marker")
+		}
+		m := img[i+1]
+		i += 2
+		switch {
+		case m == 0xff:
+			// Fill byte before a marker.
+			i--
+			continue
+		case m == 0x01 || (m >= 0xd0 && m <= 0xd7):
+			// Markers without a segment.
+			continue
+		case m == 0xd9 || m == 0xda:
+			return 0, 0, errors.New("avformat: JPEG image has no start of frame segment")
+		}
+		if i+2 > len(img) {
+			break
+		}
+		l := int(binary.BigEndian.Uint16(img[i : i+2]))
+		if m >= 0xc0 && m <= 0xcf && m != 0xc4 && m != 0xc8 && m != 0xcc {
+			// Segment length, sample precision, height and width.
+			if l < 7 || i+7 > len(img) {
+				break
+			}
+			return int(binary.BigEndian.Uint16(img[i+5 : i+7])), int(binary.BigEndian.Uint16(img[i+3 : i+5])), nil
+		}
+		i += l
+	}
+	return 0, 0, errors.New("avformat: truncated JPEG image")
+}
+
+//Write img as the only packet of st, a stream with the AV_DISPOSITION_ATTACHED_PIC disposition.
+func (ctxt *Context) WriteAttachedPicture(st *Stream, img []byte) error {
+	if len(img) == 0 {
+		return avutil.NewError(avutil.AVERROR_EINVAL)
+	}
+	ph, err := avcodec.NewPacket()
+	if err != nil {
+		return err
+	}
+	defer ph.Close()
+	pkt := ph.Packet()
+	if err := avutil.NewError(pkt.AvNewPacket(len(img))); err != nil {
+		return err
+	}
+	copy((*[MAX_ARRAY_SIZE]byte)(unsafe.Pointer(pkt.Data()))[:len(img):len(img)], img)
+	pkt.SetStreamIndex(st.Index())
+	pkt.SetFlags(avcodec.AV_PKT_FLAG_KEY)
+	pkt.SetPts(0)
+	pkt.SetDts(0)
+	return avutil.NewError(ctxt.AvInterleavedWriteFrame(pkt))
+}
@@ -22,6 +22,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/asticode/goav/avcodec"
 	"github.com/asticode/goav/avutil"
 )
 
@@ -30,29 +31,29 @@ type (
 	InputFormat                C.struct_AVInputFormat
 	OutputFormat               C.struct_AVOutputFormat
 	Context                    C.struct_AVFormatContext
-	Frame                      C.struct_AVFrame
-	CodecContext               C.struct_AVCodecContext
+	Frame                      = avutil.Frame
+	CodecContext               = avcodec.Context
 	AvIndexEntry               C.struct_AVIndexEntry
 	Stream                     C.struct_AVStream
 	AvProgram                  C.struct_AVProgram
 	AvChapter                  C.struct_AVChapter
 	AvPacketList               C.struct_AVPacketList
-	Packet                     C.struct_AVPacket
-	CodecParserContext         C.struct_AVCodecParserContext
+	Packet                     = avcodec.Packet
+	CodecParserContext         = avcodec.ParserContext
 	AvIOContext                C.struct_AVIOContext
-	AvCodec                    C.struct_AVCodec
+	AvCodec                    = avcodec.Codec
 	AvCodecTag                 C.struct_AVCodecTag
-	Class                      C.struct_AVClass
+	Class                      = avutil.Class
 	AvFormatInternal           C.struct_AVFormatInternal
 	AvIOInterruptCB            C.struct_AVIOInterruptCB
-	AvPacketSideData           C.struct_AVPacketSideData
+	AvPacketSideData           = avcodec.AvPacketSideData
 	FFFrac                     C.struct_FFFrac
 	AvStreamParseType          C.enum_AVStreamParseType
-	AvDiscard                  C.enum_AVDiscard
-	MediaType                  C.enum_AVMediaType
+	AvDiscard                  = avcodec.AvDiscard
+	MediaType                  = avutil.MediaType
 	AvDurationEstimationMethod C.enum_AVDurationEstimationMethod
-	AvPacketSideDataType       C.enum_AVPacketSideDataType
-	CodecId                    C.enum_AVCodecID
+	AvPacketSideDataType       = avcodec.AvPacketSideDataType
+	CodecId                    = avcodec.CodecId
 )
 
 const (
@@ -75,12 +76,12 @@ type File C.FILE
 
 //Allocate and read the payload of a packet and initialize its fields with default values.
 func (ctxt *AvIOContext) AvGetPacket(pkt *Packet, s int) int {
-	return int(C.av_get_packet((*C.struct_AVIOContext)(ctxt), (*C.struct_AVPacket)(pkt), C.int(s)))
+	return int(C.av_get_packet((*C.struct_AVIOContext)(ctxt), (*C.struct_AVPacket)(unsafe.Pointer(pkt)), C.int(s)))
 }
 
 //Read data and append it to the current content of the Packet.
 func (ctxt *AvIOContext) AvAppendPacket(pkt *Packet, s int) int {
-	return int(C.av_append_packet((*C.struct_AVIOContext)(ctxt), (*C.struct_AVPacket)(pkt), C.int(s)))
+	return int(C.av_append_packet((*C.struct_AVIOContext)(ctxt), (*C.struct_AVPacket)(unsafe.Pointer(pkt)), C.int(s)))
 }
 
 func (f *InputFormat) AvRegisterInputFormat() {
@@ -143,7 +144,7 @@ func AvformatAllocContext() *Context {
 
 //Get the Class for Context.
 func AvformatGetClass() *Class {
-	return (*Class)(C.avformat_get_class())
+	return (*Class)(unsafe.Pointer(C.avformat_get_class()))
 }
 
 //Get side information from stream.
@@ -257,12 +258,12 @@ func AvHexDumpLog(a, l int, b *uint8, s int) {
 
 //Send a nice dump of a packet to the specified file stream.
 func AvPktDump2(f *File, pkt *Packet, dp int, st *Stream) {
-	C.av_pkt_dump2((*C.FILE)(f), (*C.struct_AVPacket)(pkt), C.int(dp), (*C.struct_AVStream)(st))
+	C.av_pkt_dump2((*C.FILE)(f), (*C.struct_AVPacket)(unsafe.Pointer(pkt)), C.int(dp), (*C.struct_AVStream)(st))
 }
 
 //Send a nice dump of a packet to the log.
 func AvPktDumpLog2(a int, l int, pkt *Packet, dp int, st *Stream) {
-	C.av_pkt_dump_log2(unsafe.Pointer(&a), C.int(l), (*C.struct_AVPacket)(pkt), C.int(dp), (*C.struct_AVStream)(st))
+	C.av_pkt_dump_log2(unsafe.Pointer(&a), C.int(l), (*C.struct_AVPacket)(unsafe.Pointer(pkt)), C.int(dp), (*C.struct_AVStream)(st))
 }
 
 //enum CodecId av_codec_get_id (const struct AvCodecTag *const *tags, unsigned int tag)
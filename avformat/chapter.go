@@ -0,0 +1,99 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avformat
+
+/*
+	#cgo pkg-config: libavformat libavutil
+	#include <libavformat/avformat.h>
+	#include <libavutil/mem.h>
+
+	// AVChapter.id is an int64_t since libavformat 59.
+	static void goavSetChapterId(AVChapter *c, int64_t id)
+	{
+		c->id = id;
+	}
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//Chapter is a Go copy of an AVChapter.
+type Chapter struct {
+	Id       int64
+	TimeBase avutil.Rational
+	//Start and end of the chapter, in TimeBase.
+	Start    int64
+	End      int64
+	Metadata map[string]string
+}
+
+//Return the start of the chapter as a Timestamp.
+func (c Chapter) StartTimestamp() avutil.Timestamp {
+	return avutil.NewTimestamp(c.Start, c.TimeBase)
+}
+
+//Return the end of the chapter as a Timestamp.
+func (c Chapter) EndTimestamp() avutil.Timestamp {
+	return avutil.NewTimestamp(c.End, c.TimeBase)
+}
+
+//Return a Go copy of the chapter.
+func (c *AvChapter) Chapter() Chapter {
+	return Chapter{
+		Id:       int64(c.id),
+		TimeBase: *(*avutil.Rational)(unsafe.Pointer(&c.time_base)),
+		Start:    int64(c.start),
+		End:      int64(c.end),
+		Metadata: c.Metadata().Map(),
+	}
+}
+
+func (c *AvChapter) Metadata() *avutil.Dictionary {
+	return (*avutil.Dictionary)(unsafe.Pointer(c.metadata))
+}
+
+//Return Go copies of the chapters of the context.
+func (ctxt *Context) Chapters() []Chapter {
+	n := int(ctxt.nb_chapters)
+	if n == 0 || ctxt.chapters == nil {
+		return nil
+	}
+	cs := make([]Chapter, 0, n)
+	for _, c := range (*[MAX_ARRAY_SIZE]*AvChapter)(unsafe.Pointer(ctxt.chapters))[:n:n] {
+		cs = append(cs, c.Chapter())
+	}
+	return cs
+}
+
+//Append a chapter to the context, e.g. to write MP4 or Matroska chapters.
+//Chapters must be added before the header is written.
+func (ctxt *Context) AddChapter(c Chapter) error {
+	cc := (*C.struct_AVChapter)(C.av_mallocz(C.sizeof_struct_AVChapter))
+	if cc == nil {
+		return avutil.NewError(avutil.AVERROR_ENOMEM)
+	}
+	C.goavSetChapterId(cc, C.int64_t(c.Id))
+	cc.time_base = *(*C.struct_AVRational)(unsafe.Pointer(&c.TimeBase))
+	cc.start = C.int64_t(c.Start)
+	cc.end = C.int64_t(c.End)
+	for k, v := range c.Metadata {
+		if err := avutil.NewError(avutil.AvDictSet((**avutil.Dictionary)(unsafe.Pointer(&cc.metadata)), k, v, 0)); err != nil {
+			freeChapter(cc)
+			return err
+		}
+	}
+	if err := avutil.NewError(int(C.av_dynarray_add_nofree(unsafe.Pointer(&ctxt.chapters), (*C.int)(unsafe.Pointer(&ctxt.nb_chapters)), unsafe.Pointer(cc)))); err != nil {
+		freeChapter(cc)
+		return err
+	}
+	return nil
+}
+
+func freeChapter(c *C.struct_AVChapter) {
+	C.av_dict_free(&c.metadata)
+	C.av_free(unsafe.Pointer(c))
+}
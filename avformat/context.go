@@ -7,16 +7,46 @@ package avformat
 //#include <libavformat/avformat.h>
 import "C"
 import (
+	"sync"
 	"unsafe"
 
 	"github.com/asticode/goav/avcodec"
 	"github.com/asticode/goav/avutil"
 )
 
+var contextFreeHooks struct {
+	sync.RWMutex
+	fs []func(*Context)
+}
+
+//Call f with every Context about to be closed by AvformatCloseInput() or freed by AvformatFreeContext(),
+//so that the state other packages keep about it, e.g. avdevice control message handlers, can be released.
+func RegisterContextFreeHook(f func(*Context)) {
+	contextFreeHooks.Lock()
+	contextFreeHooks.fs = append(contextFreeHooks.fs, f)
+	contextFreeHooks.Unlock()
+}
+
+func runContextFreeHooks(s *Context) {
+	contextFreeHooks.RLock()
+	defer contextFreeHooks.RUnlock()
+	for _, f := range contextFreeHooks.fs {
+		f(s)
+	}
+}
+
 //Close an opened input Context.
 func AvformatCloseInput(ctxt *Context) {
+	runContextFreeHooks(ctxt)
 	var ptr *C.struct_AVFormatContext = (*C.struct_AVFormatContext)(unsafe.Pointer(ctxt))
 	C.avformat_close_input((**C.struct_AVFormatContext)(&ptr))
+	avutil.UnregisterLogContext(unsafe.Pointer(ctxt))
+}
+
+//Route messages logged by the Context, and by the contexts it is the parent of, to h.
+//If h is nil, messages go back to the global log handler.
+func (s *Context) SetLogHandler(h avutil.LogHandler) {
+	avutil.RegisterLogContext(unsafe.Pointer(s), h)
 }
 
 func (s *Context) AvFormatGetProbeScore() int {
@@ -33,7 +63,7 @@ func (s *Context) AvFormatGetVideoCodec() *AvCodec {
 
 func (s *Context) AvFormatSetVideoCodec(c *AvCodec) {
 	panic("deprecated")
-	//C.av_format_set_video_codec((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(c))
+	//C.av_format_set_video_codec((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(unsafe.Pointer(c)))
 }
 
 func (s *Context) AvFormatGetAudioCodec() *AvCodec {
@@ -44,7 +74,7 @@ func (s *Context) AvFormatGetAudioCodec() *AvCodec {
 
 func (s *Context) AvFormatSetAudioCodec(c *AvCodec) {
 	panic("deprecated")
-	//C.av_format_set_audio_codec((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(c))
+	//C.av_format_set_audio_codec((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(unsafe.Pointer(c)))
 }
 
 func (s *Context) AvFormatGetSubtitleCodec() *AvCodec {
@@ -55,7 +85,7 @@ func (s *Context) AvFormatGetSubtitleCodec() *AvCodec {
 
 func (s *Context) AvFormatSetSubtitleCodec(c *AvCodec) {
 	panic("deprecated")
-	//C.av_format_set_subtitle_codec((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(c))
+	//C.av_format_set_subtitle_codec((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(unsafe.Pointer(c)))
 }
 
 func (s *Context) AvFormatGetMetadataHeaderPadding() int {
@@ -91,12 +121,14 @@ func (s *Context) AvFmtCtxGetDurationEstimationMethod() AvDurationEstimationMeth
 
 //Free an Context and all its streams.
 func (s *Context) AvformatFreeContext() {
+	runContextFreeHooks(s)
 	C.avformat_free_context((*C.struct_AVFormatContext)(s))
+	avutil.UnregisterLogContext(unsafe.Pointer(s))
 }
 
 //Add a new stream to a media file.
 func (s *Context) AvformatNewStream(c *AvCodec) *Stream {
-	return (*Stream)(C.avformat_new_stream((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(c)))
+	return (*Stream)(C.avformat_new_stream((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(unsafe.Pointer(c))))
 }
 
 func (s *Context) AvNewProgram(id int) *AvProgram {
@@ -155,22 +187,22 @@ func (s *Context) AvformatWriteHeader(o **avutil.Dictionary) int {
 
 //Write a packet to an output media file.
 func (s *Context) AvWriteFrame(pkt *Packet) int {
-	return int(C.av_write_frame((*C.struct_AVFormatContext)(s), (*C.struct_AVPacket)(pkt)))
+	return int(C.av_write_frame((*C.struct_AVFormatContext)(s), (*C.struct_AVPacket)(unsafe.Pointer(pkt))))
 }
 
 //Write a packet to an output media file ensuring correct interleaving.
 func (s *Context) AvInterleavedWriteFrame(pkt *Packet) int {
-	return int(C.av_interleaved_write_frame((*C.struct_AVFormatContext)(s), (*C.struct_AVPacket)(pkt)))
+	return int(C.av_interleaved_write_frame((*C.struct_AVFormatContext)(s), (*C.struct_AVPacket)(unsafe.Pointer(pkt))))
 }
 
 //Write a uncoded frame to an output media file.
 func (s *Context) AvWriteUncodedFrame(si int, f *Frame) int {
-	return int(C.av_write_uncoded_frame((*C.struct_AVFormatContext)(s), C.int(si), (*C.struct_AVFrame)(f)))
+	return int(C.av_write_uncoded_frame((*C.struct_AVFormatContext)(s), C.int(si), (*C.struct_AVFrame)(unsafe.Pointer(f))))
 }
 
 //Write a uncoded frame to an output media file.
 func (s *Context) AvInterleavedWriteUncodedFrame(si int, f *Frame) int {
-	return int(C.av_interleaved_write_uncoded_frame((*C.struct_AVFormatContext)(s), C.int(si), (*C.struct_AVFrame)(f)))
+	return int(C.av_interleaved_write_uncoded_frame((*C.struct_AVFormatContext)(s), C.int(si), (*C.struct_AVFrame)(unsafe.Pointer(f))))
 }
 
 //Test whether a muxer supports uncoded frame.
@@ -201,14 +233,14 @@ func (s *Context) AvDumpFormat(i int, url string, io int) {
 
 //Guess the sample aspect ratio of a frame, based on both the stream and the frame aspect ratio.
 func (s *Context) AvGuessSampleAspectRatio(st *Stream, fr *Frame) avutil.Rational {
-	r := (C.struct_AVRational)(C.av_guess_sample_aspect_ratio((*C.struct_AVFormatContext)(s), (*C.struct_AVStream)(st), (*C.struct_AVFrame)(fr)))
+	r := (C.struct_AVRational)(C.av_guess_sample_aspect_ratio((*C.struct_AVFormatContext)(s), (*C.struct_AVStream)(st), (*C.struct_AVFrame)(unsafe.Pointer(fr))))
 
 	return *(*avutil.Rational)(unsafe.Pointer(&r))
 }
 
 //Guess the frame rate, based on both the container and codec information.
 func (s *Context) AvGuessFrameRate(st *Stream, fr *Frame) avutil.Rational {
-	r := (C.struct_AVRational)(C.av_guess_frame_rate((*C.struct_AVFormatContext)(s), (*C.struct_AVStream)(st), (*C.struct_AVFrame)(fr)))
+	r := (C.struct_AVRational)(C.av_guess_frame_rate((*C.struct_AVFormatContext)(s), (*C.struct_AVStream)(st), (*C.struct_AVFrame)(unsafe.Pointer(fr))))
 
 	return *(*avutil.Rational)(unsafe.Pointer(&r))
 }
@@ -241,5 +273,5 @@ func (s *Context) AvformatQueueAttachedPictures() int {
 
 // //void av_format_set_data_codec (Context *s, AvCodec *c)
 // func (s *Context)AvFormatSetDataCodec( c *AvCodec) {
-// 	C.av_format_set_data_codec((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(c))
+// 	C.av_format_set_data_codec((*C.struct_AVFormatContext)(s), (*C.struct_AVCodec)(unsafe.Pointer(c)))
 // }
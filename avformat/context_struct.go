@@ -23,10 +23,6 @@ import (
 	"github.com/asticode/goav/avutil"
 )
 
-func (ctxt *Context) Chapters() **AvChapter {
-	return (**AvChapter)(unsafe.Pointer(ctxt.chapters))
-}
-
 func (ctxt *Context) AudioCodec() *AvCodec {
 	return (*AvCodec)(unsafe.Pointer(ctxt.audio_codec))
 }
@@ -65,10 +61,6 @@ func (ctxt *Context) SetInterruptCallback() *int {
 	return &ret
 }
 
-func (ctxt *Context) Programs() **AvProgram {
-	return (**AvProgram)(unsafe.Pointer(ctxt.programs))
-}
-
 func (ctxt *Context) Streams() []*Stream {
 	arr := (*[MAX_ARRAY_SIZE](*Stream))(unsafe.Pointer(ctxt.streams))
 
@@ -231,6 +223,16 @@ func (ctxt *Context) StartTime() int64 {
 	return int64(ctxt.start_time)
 }
 
+//Return the start time of the format context as a Timestamp in AV_TIME_BASE_Q.
+func (ctxt *Context) StartTimestamp() avutil.Timestamp {
+	return avutil.NewTimestamp(ctxt.StartTime(), avutil.AV_TIME_BASE_Q)
+}
+
+//Return the duration of the format context as a Timestamp in AV_TIME_BASE_Q.
+func (ctxt *Context) DurationTimestamp() avutil.Timestamp {
+	return avutil.NewTimestamp(ctxt.Duration(), avutil.AV_TIME_BASE_Q)
+}
+
 func (ctxt *Context) StartTimeRealtime() int64 {
 	return int64(ctxt.start_time_realtime)
 }
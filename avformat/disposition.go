@@ -0,0 +1,129 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avformat
+
+/*
+	#cgo pkg-config: libavformat
+	#include <stdlib.h>
+	#include <libavformat/avformat.h>
+
+	// Dispositions added after libavformat 58.0 are 0 when the headers do not define them.
+	#ifdef AV_DISPOSITION_DEPENDENT
+	#define GOAV_DISPOSITION_DEPENDENT AV_DISPOSITION_DEPENDENT
+	#else
+	#define GOAV_DISPOSITION_DEPENDENT 0
+	#endif
+	#ifdef AV_DISPOSITION_STILL_IMAGE
+	#define GOAV_DISPOSITION_STILL_IMAGE AV_DISPOSITION_STILL_IMAGE
+	#else
+	#define GOAV_DISPOSITION_STILL_IMAGE 0
+	#endif
+*/
+import "C"
+import (
+	"strings"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//Disposition is a set of AV_DISPOSITION_* flags describing how a stream is meant to be presented.
+type Disposition int
+
+const (
+	AV_DISPOSITION_DEFAULT          Disposition = C.AV_DISPOSITION_DEFAULT
+	AV_DISPOSITION_DUB              Disposition = C.AV_DISPOSITION_DUB
+	AV_DISPOSITION_ORIGINAL         Disposition = C.AV_DISPOSITION_ORIGINAL
+	AV_DISPOSITION_COMMENT          Disposition = C.AV_DISPOSITION_COMMENT
+	AV_DISPOSITION_LYRICS           Disposition = C.AV_DISPOSITION_LYRICS
+	AV_DISPOSITION_KARAOKE          Disposition = C.AV_DISPOSITION_KARAOKE
+	AV_DISPOSITION_FORCED           Disposition = C.AV_DISPOSITION_FORCED
+	AV_DISPOSITION_HEARING_IMPAIRED Disposition = C.AV_DISPOSITION_HEARING_IMPAIRED
+	AV_DISPOSITION_VISUAL_IMPAIRED  Disposition = C.AV_DISPOSITION_VISUAL_IMPAIRED
+	AV_DISPOSITION_CLEAN_EFFECTS    Disposition = C.AV_DISPOSITION_CLEAN_EFFECTS
+	AV_DISPOSITION_ATTACHED_PIC     Disposition = C.AV_DISPOSITION_ATTACHED_PIC
+	AV_DISPOSITION_TIMED_THUMBNAILS Disposition = C.AV_DISPOSITION_TIMED_THUMBNAILS
+	AV_DISPOSITION_CAPTIONS         Disposition = C.AV_DISPOSITION_CAPTIONS
+	AV_DISPOSITION_DESCRIPTIONS     Disposition = C.AV_DISPOSITION_DESCRIPTIONS
+	AV_DISPOSITION_METADATA         Disposition = C.AV_DISPOSITION_METADATA
+	//0 if the version of libavformat does not support it.
+	AV_DISPOSITION_DEPENDENT Disposition = C.GOAV_DISPOSITION_DEPENDENT
+	//0 if the version of libavformat does not support it.
+	AV_DISPOSITION_STILL_IMAGE Disposition = C.GOAV_DISPOSITION_STILL_IMAGE
+)
+
+//Flags with the names ffprobe gives them, in the order it prints them.
+//Flags the version of libavformat does not support are left out by init().
+var dispositionNames = []struct {
+	flag Disposition
+	name string
+}{
+	{AV_DISPOSITION_DEFAULT, "default"},
+	{AV_DISPOSITION_DUB, "dub"},
+	{AV_DISPOSITION_ORIGINAL, "original"},
+	{AV_DISPOSITION_COMMENT, "comment"},
+	{AV_DISPOSITION_LYRICS, "lyrics"},
+	{AV_DISPOSITION_KARAOKE, "karaoke"},
+	{AV_DISPOSITION_FORCED, "forced"},
+	{AV_DISPOSITION_HEARING_IMPAIRED, "hearing_impaired"},
+	{AV_DISPOSITION_VISUAL_IMPAIRED, "visual_impaired"},
+	{AV_DISPOSITION_CLEAN_EFFECTS, "clean_effects"},
+	{AV_DISPOSITION_ATTACHED_PIC, "attached_pic"},
+	{AV_DISPOSITION_TIMED_THUMBNAILS, "timed_thumbnails"},
+	{AV_DISPOSITION_CAPTIONS, "captions"},
+	{AV_DISPOSITION_DESCRIPTIONS, "descriptions"},
+	{AV_DISPOSITION_METADATA, "metadata"},
+	{AV_DISPOSITION_DEPENDENT, "dependent"},
+	{AV_DISPOSITION_STILL_IMAGE, "still_image"},
+}
+
+func init() {
+	names := dispositionNames[:0]
+	for _, n := range dispositionNames {
+		if n.flag != 0 {
+			names = append(names, n)
+		}
+	}
+	dispositionNames = names
+}
+
+//Return whether all the flags of f are set. It returns false if f is 0, e.g. a flag not supported by libavformat.
+func (d Disposition) Has(f Disposition) bool {
+	return f != 0 && d&f == f
+}
+
+//Return the names of the flags that are set, e.g. "default+forced", or "0" if none is.
+func (d Disposition) String() string {
+	var names []string
+	for _, n := range dispositionNames {
+		if d.Has(n.flag) {
+			names = append(names, n.name)
+		}
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, "+")
+}
+
+func (avs *Stream) Disposition() Disposition {
+	return Disposition(avs.disposition)
+}
+
+//Set the disposition of the stream, before the header is written when muxing.
+func (avs *Stream) SetDisposition(d Disposition) {
+	avs.disposition = C.int(d)
+}
+
+//Set the metadata entry key of the stream to value, or remove it if value is empty.
+func (avs *Stream) SetMetadata(key, value string) error {
+	ck := C.CString(key)
+	defer C.free(unsafe.Pointer(ck))
+	var cv *C.char
+	if value != "" {
+		cv = C.CString(value)
+		defer C.free(unsafe.Pointer(cv))
+	}
+	return avutil.NewError(int(C.av_dict_set(&avs.metadata, ck, cv, 0)))
+}
@@ -0,0 +1,59 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avformat
+
+//#cgo pkg-config: libavformat
+//#include <libavformat/avformat.h>
+/*
+static void goavCloseOutput(AVFormatContext *s)
+{
+	if (s->oformat && !(s->oformat->flags & AVFMT_NOFILE))
+		avio_closep(&s->pb);
+}
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//ContextHandle owns a format Context opened by OpenInput() or allocated by NewOutputContext().
+type ContextHandle struct {
+	*avutil.Handle
+}
+
+//Open the input url, owned by the returned handle, which must be closed.
+func OpenInput(url string, fmt *InputFormat, opts **avutil.Dictionary) (*ContextHandle, error) {
+	var ctx *Context
+	if err := avutil.NewError(AvformatOpenInput(&ctx, url, fmt, opts)); err != nil {
+		return nil, err
+	}
+	return &ContextHandle{avutil.NewHandle("avformat.Context", unsafe.Pointer(ctx), func(p unsafe.Pointer) {
+		AvformatCloseInput((*Context)(p))
+	})}, nil
+}
+
+//Allocate an output Context, owned by the returned handle, which must be closed.
+//Closing the handle also closes the output file opened in the context, unless the format has the AVFMT_NOFILE flag.
+func NewOutputContext(o *OutputFormat, formatName, filename string) (*ContextHandle, error) {
+	var ctx *Context
+	if err := avutil.NewError(AvformatAllocOutputContext2(&ctx, o, formatName, filename)); err != nil {
+		return nil, err
+	}
+	return &ContextHandle{avutil.NewHandle("avformat.Context", unsafe.Pointer(ctx), func(p unsafe.Pointer) {
+		C.goavCloseOutput((*C.struct_AVFormatContext)(p))
+		(*Context)(p).AvformatFreeContext()
+	})}, nil
+}
+
+//Return the format context owned by the handle, or nil once it is closed or released.
+func (c *ContextHandle) Context() *Context {
+	return (*Context)(c.Pointer())
+}
+
+//Give up the ownership of the format context, which must then be closed or freed by the caller.
+func (c *ContextHandle) Release() *Context {
+	return (*Context)(c.Handle.Release())
+}
@@ -0,0 +1,343 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avformat
+
+/*
+	#cgo pkg-config: libavformat libavcodec libavutil
+	#include <libavformat/avformat.h>
+	#include <libavcodec/avcodec.h>
+	#include <libavutil/avutil.h>
+	#include <libavutil/pixdesc.h>
+	#include <libavutil/samplefmt.h>
+*/
+import "C"
+import (
+	"fmt"
+	"strconv"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//ProbeResult describes a media file the way `ffprobe -print_format json -show_format -show_streams -show_chapters -show_programs` does.
+//It serializes to JSON with the field names of ffprobe.
+type ProbeResult struct {
+	Programs []ProbeProgram `json:"programs"`
+	Streams  []ProbeStream  `json:"streams"`
+	Chapters []ProbeChapter `json:"chapters"`
+	Format   ProbeFormat    `json:"format"`
+}
+
+//ProbeFormat describes the container of a media file.
+type ProbeFormat struct {
+	Filename       string            `json:"filename"`
+	NbStreams      int               `json:"nb_streams"`
+	NbPrograms     int               `json:"nb_programs"`
+	FormatName     string            `json:"format_name"`
+	FormatLongName string            `json:"format_long_name,omitempty"`
+	StartTime      string            `json:"start_time,omitempty"`
+	Duration       string            `json:"duration,omitempty"`
+	Size           string            `json:"size,omitempty"`
+	BitRate        string            `json:"bit_rate,omitempty"`
+	ProbeScore     int               `json:"probe_score"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+//ProbeStream describes a stream of a media file.
+type ProbeStream struct {
+	Index              int               `json:"index"`
+	CodecName          string            `json:"codec_name,omitempty"`
+	CodecLongName      string            `json:"codec_long_name,omitempty"`
+	Profile            string            `json:"profile,omitempty"`
+	CodecType          string            `json:"codec_type,omitempty"`
+	CodecTagString     string            `json:"codec_tag_string"`
+	CodecTag           string            `json:"codec_tag"`
+	Width              int               `json:"width,omitempty"`
+	Height             int               `json:"height,omitempty"`
+	HasBFrames         int               `json:"has_b_frames,omitempty"`
+	SampleAspectRatio  string            `json:"sample_aspect_ratio,omitempty"`
+	DisplayAspectRatio string            `json:"display_aspect_ratio,omitempty"`
+	PixFmt             string            `json:"pix_fmt,omitempty"`
+	Level              int               `json:"level,omitempty"`
+	ColorRange         string            `json:"color_range,omitempty"`
+	ColorSpace         string            `json:"color_space,omitempty"`
+	ColorTransfer      string            `json:"color_transfer,omitempty"`
+	ColorPrimaries     string            `json:"color_primaries,omitempty"`
+	ChromaLocation     string            `json:"chroma_location,omitempty"`
+	FieldOrder         string            `json:"field_order,omitempty"`
+	SampleFmt          string            `json:"sample_fmt,omitempty"`
+	SampleRate         string            `json:"sample_rate,omitempty"`
+	Channels           int               `json:"channels,omitempty"`
+	ChannelLayout      string            `json:"channel_layout,omitempty"`
+	BitsPerSample      int               `json:"bits_per_sample,omitempty"`
+	Id                 string            `json:"id,omitempty"`
+	RFrameRate         string            `json:"r_frame_rate"`
+	AvgFrameRate       string            `json:"avg_frame_rate"`
+	TimeBase           string            `json:"time_base"`
+	StartPts           *int64            `json:"start_pts,omitempty"`
+	StartTime          string            `json:"start_time,omitempty"`
+	DurationTs         *int64            `json:"duration_ts,omitempty"`
+	Duration           string            `json:"duration,omitempty"`
+	BitRate            string            `json:"bit_rate,omitempty"`
+	BitsPerRawSample   string            `json:"bits_per_raw_sample,omitempty"`
+	NbFrames           string            `json:"nb_frames,omitempty"`
+	Disposition        map[string]int    `json:"disposition"`
+	Tags               map[string]string `json:"tags,omitempty"`
+}
+
+//ProbeChapter describes a chapter of a media file.
+type ProbeChapter struct {
+	Id        int64             `json:"id"`
+	TimeBase  string            `json:"time_base"`
+	Start     int64             `json:"start"`
+	StartTime string            `json:"start_time,omitempty"`
+	End       int64             `json:"end"`
+	EndTime   string            `json:"end_time,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+//ProbeProgram describes a program of a media file, e.g. a service of an MPEG-TS.
+type ProbeProgram struct {
+	ProgramId  int               `json:"program_id"`
+	ProgramNum int               `json:"program_num"`
+	NbStreams  int               `json:"nb_streams"`
+	PmtPid     int               `json:"pmt_pid"`
+	PcrPid     int               `json:"pcr_pid"`
+	StartPts   *int64            `json:"start_pts,omitempty"`
+	StartTime  string            `json:"start_time,omitempty"`
+	EndPts     *int64            `json:"end_pts,omitempty"`
+	EndTime    string            `json:"end_time,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Streams    []ProbeStream     `json:"streams"`
+}
+
+//Open url, read its stream information and describe it like ffprobe does.
+//opts are passed to AvformatOpenInput() and are replaced with the options that were not found.
+func Probe(url string, opts **avutil.Dictionary) (*ProbeResult, error) {
+	h, err := OpenInput(url, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Close()
+	s := h.Context()
+	if err := avutil.NewError(s.AvformatFindStreamInfo(nil)); err != nil {
+		return nil, err
+	}
+	return s.ProbeResult(url), nil
+}
+
+//Describe the opened input like ffprobe does. filename is reported as the name of the input.
+func (s *Context) ProbeResult(filename string) *ProbeResult {
+	r := &ProbeResult{
+		Programs: []ProbeProgram{},
+		Streams:  []ProbeStream{},
+		Chapters: []ProbeChapter{},
+		Format:   s.probeFormat(filename),
+	}
+	for _, st := range s.Streams() {
+		r.Streams = append(r.Streams, s.probeStream(st))
+	}
+
+	for _, cp := range s.avPrograms() {
+		p := cp.Program()
+		pp := ProbeProgram{
+			ProgramId:  p.Id,
+			ProgramNum: p.ProgramNum,
+			NbStreams:  len(p.StreamIndexes),
+			PmtPid:     p.PmtPid,
+			PcrPid:     p.PcrPid,
+			StartPts:   probeTs(cp.StartTime()),
+			StartTime:  probeTime(cp.StartTime(), avutil.AV_TIME_BASE_Q),
+			EndPts:     probeTs(cp.EndTime()),
+			EndTime:    probeTime(cp.EndTime(), avutil.AV_TIME_BASE_Q),
+			Tags:       p.Metadata,
+			Streams:    []ProbeStream{},
+		}
+		for _, i := range p.StreamIndexes {
+			if i < len(r.Streams) {
+				pp.Streams = append(pp.Streams, r.Streams[i])
+			}
+		}
+		r.Programs = append(r.Programs, pp)
+	}
+
+	for _, c := range s.Chapters() {
+		r.Chapters = append(r.Chapters, ProbeChapter{
+			Id:        c.Id,
+			TimeBase:  probeRational(c.TimeBase, '/'),
+			Start:     c.Start,
+			StartTime: probeTime(c.Start, c.TimeBase),
+			End:       c.End,
+			EndTime:   probeTime(c.End, c.TimeBase),
+			Tags:      c.Metadata,
+		})
+	}
+	return r
+}
+
+func (s *Context) probeFormat(filename string) ProbeFormat {
+	cs := (*C.struct_AVFormatContext)(unsafe.Pointer(s))
+	f := ProbeFormat{
+		Filename:   filename,
+		NbStreams:  int(cs.nb_streams),
+		NbPrograms: int(cs.nb_programs),
+		StartTime:  probeTime(int64(cs.start_time), avutil.AV_TIME_BASE_Q),
+		Duration:   probeTime(int64(cs.duration), avutil.AV_TIME_BASE_Q),
+		ProbeScore: int(cs.probe_score),
+		Tags:       s.Metadata().Map(),
+	}
+	if cs.iformat != nil {
+		f.FormatName = C.GoString(cs.iformat.name)
+		f.FormatLongName = C.GoString(cs.iformat.long_name)
+	}
+	if cs.pb != nil {
+		if size := int64(C.avio_size(cs.pb)); size >= 0 {
+			f.Size = strconv.FormatInt(size, 10)
+		}
+	}
+	if cs.bit_rate > 0 {
+		f.BitRate = strconv.FormatInt(int64(cs.bit_rate), 10)
+	}
+	return f
+}
+
+func (s *Context) probeStream(st *Stream) ProbeStream {
+	cst := (*C.struct_AVStream)(unsafe.Pointer(st))
+	par := cst.codecpar
+	tb := st.TimeBase()
+	p := ProbeStream{
+		Index:          int(cst.index),
+		CodecType:      avutil.AvGetMediaTypeString(MediaType(par.codec_type)),
+		CodecTagString: probeFourCC(uint32(par.codec_tag)),
+		CodecTag:       fmt.Sprintf("0x%04x", uint32(par.codec_tag)),
+		RFrameRate:     probeRational(st.RFrameRate(), '/'),
+		AvgFrameRate:   probeRational(st.AvgFrameRate(), '/'),
+		TimeBase:       probeRational(tb, '/'),
+		StartPts:       probeTs(int64(cst.start_time)),
+		StartTime:      probeTime(int64(cst.start_time), tb),
+		DurationTs:     probeTs(int64(cst.duration)),
+		Duration:       probeTime(int64(cst.duration), tb),
+		Disposition:    make(map[string]int, len(dispositionNames)),
+		Tags:           st.Metadata().Map(),
+	}
+
+	if d := C.avcodec_descriptor_get(par.codec_id); d != nil {
+		p.CodecName = C.GoString(d.name)
+		p.CodecLongName = C.GoString(d.long_name)
+	} else {
+		p.CodecName = "unknown"
+	}
+	if name := C.avcodec_profile_name(par.codec_id, par.profile); name != nil {
+		p.Profile = C.GoString(name)
+	} else if par.profile != C.FF_PROFILE_UNKNOWN {
+		p.Profile = strconv.Itoa(int(par.profile))
+	}
+	if ifmt := s.Iformat(); ifmt != nil && ifmt.flags&C.AVFMT_SHOW_IDS != 0 {
+		p.Id = fmt.Sprintf("0x%x", int(cst.id))
+	}
+	if par.bit_rate > 0 {
+		p.BitRate = strconv.FormatInt(int64(par.bit_rate), 10)
+	}
+	if par.bits_per_raw_sample > 0 {
+		p.BitsPerRawSample = strconv.Itoa(int(par.bits_per_raw_sample))
+	}
+	if cst.nb_frames > 0 {
+		p.NbFrames = strconv.FormatInt(int64(cst.nb_frames), 10)
+	}
+	for _, d := range dispositionNames {
+		p.Disposition[d.name] = 0
+		if st.Disposition().Has(d.flag) {
+			p.Disposition[d.name] = 1
+		}
+	}
+
+	switch par.codec_type {
+	case C.AVMEDIA_TYPE_VIDEO:
+		p.Width = int(par.width)
+		p.Height = int(par.height)
+		p.HasBFrames = int(par.video_delay)
+		sar := s.AvGuessSampleAspectRatio(st, nil)
+		if sar.Num() != 0 {
+			p.SampleAspectRatio = probeRational(sar, ':')
+			dar, _ := avutil.AvReduce(int64(p.Width)*int64(sar.Num()), int64(p.Height)*int64(sar.Den()), 1024*1024)
+			p.DisplayAspectRatio = probeRational(dar, ':')
+		}
+		if name := C.av_get_pix_fmt_name((C.enum_AVPixelFormat)(par.format)); name != nil {
+			p.PixFmt = C.GoString(name)
+		}
+		p.Level = int(par.level)
+		if par.color_range != C.AVCOL_RANGE_UNSPECIFIED {
+			p.ColorRange = C.GoString(C.av_color_range_name(par.color_range))
+		}
+		if par.color_space != C.AVCOL_SPC_UNSPECIFIED {
+			p.ColorSpace = C.GoString(C.av_color_space_name(par.color_space))
+		}
+		if par.color_trc != C.AVCOL_TRC_UNSPECIFIED {
+			p.ColorTransfer = C.GoString(C.av_color_transfer_name(par.color_trc))
+		}
+		if par.color_primaries != C.AVCOL_PRI_UNSPECIFIED {
+			p.ColorPrimaries = C.GoString(C.av_color_primaries_name(par.color_primaries))
+		}
+		if par.chroma_location != C.AVCHROMA_LOC_UNSPECIFIED {
+			p.ChromaLocation = C.GoString(C.av_chroma_location_name(par.chroma_location))
+		}
+		p.FieldOrder = probeFieldOrder(par.field_order)
+	case C.AVMEDIA_TYPE_AUDIO:
+		if name := C.av_get_sample_fmt_name((C.enum_AVSampleFormat)(par.format)); name != nil {
+			p.SampleFmt = C.GoString(name)
+		}
+		p.SampleRate = strconv.Itoa(int(par.sample_rate))
+		p.Channels = int(par.channels)
+		if par.channel_layout != 0 {
+			p.ChannelLayout = avutil.AvGetChannelLayoutString(int(par.channels), uint64(par.channel_layout))
+		}
+		p.BitsPerSample = int(C.av_get_bits_per_sample(par.codec_id))
+	case C.AVMEDIA_TYPE_SUBTITLE:
+		p.Width = int(par.width)
+		p.Height = int(par.height)
+	}
+	return p
+}
+
+//Return ts, or nil if it is undefined.
+func probeTs(ts int64) *int64 {
+	if ts == avutil.AV_NOPTS_VALUE {
+		return nil
+	}
+	return &ts
+}
+
+//Return ts in seconds, formatted like ffprobe does, or an empty string if it is undefined.
+func probeTime(ts int64, tb avutil.Rational) string {
+	if ts == avutil.AV_NOPTS_VALUE {
+		return ""
+	}
+	return strconv.FormatFloat(float64(ts)*tb.ToDouble(), 'f', 6, 64)
+}
+
+func probeRational(r avutil.Rational, sep byte) string {
+	return strconv.Itoa(r.Num()) + string(sep) + strconv.Itoa(r.Den())
+}
+
+//Format a codec tag like ffprobe does.
+func probeFourCC(tag uint32) string {
+	var buf [C.AV_FOURCC_MAX_STRING_SIZE]C.char
+	return C.GoString(C.av_fourcc_make_string(&buf[0], C.uint32_t(tag)))
+}
+
+func probeFieldOrder(o C.enum_AVFieldOrder) string {
+	switch o {
+	case C.AV_FIELD_PROGRESSIVE:
+		return "progressive"
+	case C.AV_FIELD_TT:
+		return "tt"
+	case C.AV_FIELD_BB:
+		return "bb"
+	case C.AV_FIELD_TB:
+		return "tb"
+	case C.AV_FIELD_BT:
+		return "bt"
+	}
+	return ""
+}
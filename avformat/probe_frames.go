@@ -0,0 +1,450 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avformat
+
+/*
+	#cgo pkg-config: libavformat libavcodec libavutil
+	#include <libavformat/avformat.h>
+	#include <libavcodec/avcodec.h>
+	#include <libavutil/pixdesc.h>
+	#include <libavutil/samplefmt.h>
+*/
+import "C"
+import (
+	"strconv"
+	"unsafe"
+
+	"github.com/asticode/goav/avcodec"
+	"github.com/asticode/goav/avutil"
+)
+
+//FrameProbeOptions selects what ProbeFrames() reads and reports.
+type FrameProbeOptions struct {
+	//Indexes of the streams to report, all streams if empty.
+	Streams []int
+	//Report every packet, like `ffprobe -show_packets`.
+	Packets bool
+	//Decode the packets and report every frame, like `ffprobe -show_frames`.
+	Frames bool
+	//Stop after this many packets of the reported streams, 0 for no limit.
+	MaxPackets int
+}
+
+//FrameProbeResult holds the packets and frames of a media file along with per stream timing reports.
+//It serializes to JSON with the field names of ffprobe.
+type FrameProbeResult struct {
+	Packets []PacketReport `json:"packets,omitempty"`
+	Frames  []FrameReport  `json:"frames,omitempty"`
+	Streams []StreamReport `json:"streams"`
+}
+
+//PacketReport describes a packet like `ffprobe -show_packets` does.
+type PacketReport struct {
+	CodecType    string `json:"codec_type"`
+	StreamIndex  int    `json:"stream_index"`
+	Pts          *int64 `json:"pts,omitempty"`
+	PtsTime      string `json:"pts_time,omitempty"`
+	Dts          *int64 `json:"dts,omitempty"`
+	DtsTime      string `json:"dts_time,omitempty"`
+	Duration     int64  `json:"duration"`
+	DurationTime string `json:"duration_time"`
+	Size         string `json:"size"`
+	Pos          string `json:"pos,omitempty"`
+	//"K" for a keyframe and "D" for a packet to discard, "_" otherwise.
+	Flags string `json:"flags"`
+}
+
+//FrameReport describes a decoded frame like `ffprobe -show_frames` does.
+type FrameReport struct {
+	MediaType               string `json:"media_type"`
+	StreamIndex             int    `json:"stream_index"`
+	KeyFrame                int    `json:"key_frame"`
+	Pts                     *int64 `json:"pts,omitempty"`
+	PtsTime                 string `json:"pts_time,omitempty"`
+	PktDts                  *int64 `json:"pkt_dts,omitempty"`
+	PktDtsTime              string `json:"pkt_dts_time,omitempty"`
+	BestEffortTimestamp     *int64 `json:"best_effort_timestamp,omitempty"`
+	BestEffortTimestampTime string `json:"best_effort_timestamp_time,omitempty"`
+	PktDuration             int64  `json:"pkt_duration"`
+	PktDurationTime         string `json:"pkt_duration_time"`
+	PktPos                  string `json:"pkt_pos,omitempty"`
+	PktSize                 string `json:"pkt_size,omitempty"`
+	Width                   int    `json:"width,omitempty"`
+	Height                  int    `json:"height,omitempty"`
+	PixFmt                  string `json:"pix_fmt,omitempty"`
+	PictType                string `json:"pict_type,omitempty"`
+	InterlacedFrame         int    `json:"interlaced_frame"`
+	TopFieldFirst           int    `json:"top_field_first"`
+	RepeatPict              int    `json:"repeat_pict"`
+	SampleFmt               string `json:"sample_fmt,omitempty"`
+	NbSamples               int    `json:"nb_samples,omitempty"`
+	Channels                int    `json:"channels,omitempty"`
+	ChannelLayout           string `json:"channel_layout,omitempty"`
+}
+
+//TimestampIssueKind is the kind of a timestamp problem found by ProbeFrames().
+type TimestampIssueKind string
+
+const (
+	//A packet has no pts.
+	TimestampMissingPts TimestampIssueKind = "missing_pts"
+	//A packet has no dts.
+	TimestampMissingDts TimestampIssueKind = "missing_dts"
+	//The dts of a packet is not greater than the dts of the previous packet of the stream.
+	TimestampNonMonotonicDts TimestampIssueKind = "non_monotonic_dts"
+	//The pts of a packet is lower than its dts.
+	TimestampPtsBeforeDts TimestampIssueKind = "pts_before_dts"
+	//The best effort timestamp of a frame is not greater than the one of the previous frame of the stream.
+	TimestampNonMonotonicFramePts TimestampIssueKind = "non_monotonic_frame_pts"
+)
+
+//TimestampIssue is a timestamp problem of a packet or frame.
+type TimestampIssue struct {
+	Kind TimestampIssueKind `json:"kind"`
+	//Index of the packet, or of the frame for frame issues, among the ones of the stream.
+	Index int `json:"index"`
+	//Offending timestamp, and the timestamp it was compared to, in the time base of the stream.
+	Value    *int64 `json:"value,omitempty"`
+	Previous *int64 `json:"previous,omitempty"`
+}
+
+//DecodeError is a failure of the decoder of a stream found by ProbeFrames().
+type DecodeError struct {
+	//Index of the packet sent to the decoder among the ones of the stream, or -1 when opening or flushing it.
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+//StreamReport sums up the packets and frames of a stream.
+type StreamReport struct {
+	Index        int    `json:"index"`
+	CodecType    string `json:"codec_type"`
+	NbPackets    int    `json:"nb_packets"`
+	NbKeyPackets int    `json:"nb_key_packets"`
+	NbFrames     int    `json:"nb_frames"`
+	//Number of packets from each keyframe to the next one. The last GOP is the one cut by the end of the input.
+	GopSizes []int            `json:"gop_sizes,omitempty"`
+	Issues   []TimestampIssue `json:"timestamp_issues,omitempty"`
+	//Errors of the decoder when o.Frames is set. A stream whose decoder fails to open is not decoded.
+	DecodeErrors []DecodeError `json:"decode_errors,omitempty"`
+}
+
+type frameProbeStream struct {
+	st      *Stream
+	report  *StreamReport
+	dec     *avcodec.ContextHandle
+	lastDts int64
+	lastPts int64
+	gop     int
+}
+
+//Open url and read its packets, decoding them if o.Frames is set, to report its GOP structure and timestamps.
+//opts are passed to AvformatOpenInput() and are replaced with the options that were not found.
+func ProbeFrames(url string, opts **avutil.Dictionary, o FrameProbeOptions) (*FrameProbeResult, error) {
+	h, err := OpenInput(url, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Close()
+	s := h.Context()
+	if err := avutil.NewError(s.AvformatFindStreamInfo(nil)); err != nil {
+		return nil, err
+	}
+	return s.ProbeFrames(o)
+}
+
+//Read the packets of the opened input, decoding them if o.Frames is set, to report its GOP structure and timestamps.
+//Packets the decoder rejects are reported but produce no frames, and the errors of the decoders are recorded in
+//the reports of their streams.
+func (s *Context) ProbeFrames(o FrameProbeOptions) (*FrameProbeResult, error) {
+	streams := s.Streams()
+	r := &FrameProbeResult{}
+	ps := make([]*frameProbeStream, len(streams))
+	indexes := o.Streams
+	if len(indexes) == 0 {
+		for i := range streams {
+			indexes = append(indexes, i)
+		}
+	}
+	r.Streams = make([]StreamReport, 0, len(indexes))
+	for _, i := range indexes {
+		if i < 0 || i >= len(streams) || ps[i] != nil {
+			continue
+		}
+		r.Streams = append(r.Streams, StreamReport{
+			Index:     i,
+			CodecType: avutil.AvGetMediaTypeString(streams[i].CodecParameters().CodecType()),
+		})
+		ps[i] = &frameProbeStream{st: streams[i], lastDts: avutil.AV_NOPTS_VALUE, lastPts: avutil.AV_NOPTS_VALUE}
+	}
+	// Reports are only referenced once r.Streams is fully grown.
+	for i := range r.Streams {
+		ps[r.Streams[i].Index].report = &r.Streams[i]
+	}
+
+	if o.Frames {
+		for _, p := range ps {
+			if p == nil {
+				continue
+			}
+			dec, err := openProbeDecoder(p.st)
+			if err != nil {
+				// The stream is still reported at the packet level.
+				p.decodeError(-1, err)
+				continue
+			}
+			p.dec = dec
+		}
+		defer closeProbeDecoders(ps)
+	}
+
+	ph, err := avcodec.NewPacket()
+	if err != nil {
+		return nil, err
+	}
+	defer ph.Close()
+	pkt := ph.Packet()
+	fh, err := avutil.NewFrame()
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	f := fh.Frame()
+
+	for n := 0; o.MaxPackets <= 0 || n < o.MaxPackets; {
+		ret := s.AvReadFrame(pkt)
+		if ret == avutil.AVERROR_EOF {
+			break
+		}
+		if err := avutil.NewError(ret); err != nil {
+			return nil, err
+		}
+		if i := pkt.StreamIndex(); i < len(ps) && ps[i] != nil {
+			n++
+			p := ps[i]
+			p.packet(pkt)
+			if o.Packets {
+				r.Packets = append(r.Packets, p.packetReport(pkt))
+			}
+			if p.dec != nil {
+				if err := p.decode(pkt, f, r); err != nil {
+					return nil, err
+				}
+			}
+		}
+		pkt.AvPacketUnref()
+	}
+
+	for _, p := range ps {
+		if p == nil {
+			continue
+		}
+		if p.dec != nil {
+			if err := p.decode(nil, f, r); err != nil {
+				return nil, err
+			}
+		}
+		if p.gop > 0 {
+			p.report.GopSizes = append(p.report.GopSizes, p.gop)
+		}
+	}
+	return r, nil
+}
+
+func openProbeDecoder(st *Stream) (*avcodec.ContextHandle, error) {
+	par := st.CodecParameters()
+	codec := avcodec.AvcodecFindDecoder(par.CodecId())
+	if codec == nil {
+		// Streams without a decoder are still reported at the packet level.
+		return nil, nil
+	}
+	dec, err := avcodec.NewContext(codec)
+	if err != nil {
+		return nil, err
+	}
+	if err := avutil.NewError(avcodec.AvcodecParametersToContext(dec.Context(), par)); err != nil {
+		dec.Close()
+		return nil, err
+	}
+	(*C.struct_AVCodecContext)(unsafe.Pointer(dec.Context())).pkt_timebase = (*C.struct_AVStream)(unsafe.Pointer(st)).time_base
+	if err := avutil.NewError(dec.Context().AvcodecOpen2(codec, nil)); err != nil {
+		dec.Close()
+		return nil, err
+	}
+	return dec, nil
+}
+
+func closeProbeDecoders(ps []*frameProbeStream) {
+	for _, p := range ps {
+		if p != nil && p.dec != nil {
+			p.dec.Close()
+			p.dec = nil
+		}
+	}
+}
+
+//Update the counters, GOP sizes and timestamp checks of the stream with pkt.
+func (p *frameProbeStream) packet(pkt *avcodec.Packet) {
+	r := p.report
+	index := r.NbPackets
+	r.NbPackets++
+
+	if pkt.Flags()&avcodec.AV_PKT_FLAG_KEY != 0 {
+		r.NbKeyPackets++
+		if p.gop > 0 {
+			r.GopSizes = append(r.GopSizes, p.gop)
+		}
+		p.gop = 1
+	} else if p.gop > 0 {
+		p.gop++
+	}
+
+	pts, dts := pkt.Pts(), pkt.Dts()
+	if pts == avutil.AV_NOPTS_VALUE {
+		r.Issues = append(r.Issues, TimestampIssue{Kind: TimestampMissingPts, Index: index})
+	}
+	if dts == avutil.AV_NOPTS_VALUE {
+		r.Issues = append(r.Issues, TimestampIssue{Kind: TimestampMissingDts, Index: index})
+		return
+	}
+	if p.lastDts != avutil.AV_NOPTS_VALUE && dts <= p.lastDts {
+		r.Issues = append(r.Issues, TimestampIssue{Kind: TimestampNonMonotonicDts, Index: index, Value: probeTs(dts), Previous: probeTs(p.lastDts)})
+	}
+	if pts != avutil.AV_NOPTS_VALUE && pts < dts {
+		r.Issues = append(r.Issues, TimestampIssue{Kind: TimestampPtsBeforeDts, Index: index, Value: probeTs(pts), Previous: probeTs(dts)})
+	}
+	p.lastDts = dts
+}
+
+func (p *frameProbeStream) packetReport(pkt *avcodec.Packet) PacketReport {
+	tb := p.st.TimeBase()
+	r := PacketReport{
+		CodecType:    p.report.CodecType,
+		StreamIndex:  p.report.Index,
+		Pts:          probeTs(pkt.Pts()),
+		PtsTime:      probeTime(pkt.Pts(), tb),
+		Dts:          probeTs(pkt.Dts()),
+		DtsTime:      probeTime(pkt.Dts(), tb),
+		Duration:     pkt.Duration(),
+		DurationTime: probeTime(pkt.Duration(), tb),
+		Size:         strconv.Itoa(pkt.Size()),
+		Flags:        "__",
+	}
+	if pos := pkt.Pos(); pos >= 0 {
+		r.Pos = strconv.FormatInt(pos, 10)
+	}
+	flags := []byte(r.Flags)
+	if pkt.Flags()&avcodec.AV_PKT_FLAG_KEY != 0 {
+		flags[0] = 'K'
+	}
+	if pkt.Flags()&avcodec.AV_PKT_FLAG_DISCARD != 0 {
+		flags[1] = 'D'
+	}
+	r.Flags = string(flags)
+	return r
+}
+
+//Send pkt to the decoder of the stream, or flush it if pkt is nil, and report the frames it outputs.
+func (p *frameProbeStream) decode(pkt *avcodec.Packet, f *avutil.Frame, r *FrameProbeResult) error {
+	index := -1
+	if pkt != nil {
+		index = p.report.NbPackets - 1
+	}
+	ret := p.dec.Context().SendPacket(pkt)
+	if ret == avutil.AVERROR_EAGAIN {
+		// The decoder only accepts the packet once the frames it holds are received.
+		if err := p.receive(index, f, r); err != nil {
+			return err
+		}
+		ret = p.dec.Context().SendPacket(pkt)
+	}
+	if ret < 0 && ret != avutil.AVERROR_EOF {
+		if ret == avutil.AVERROR_ENOMEM {
+			return avutil.NewError(ret)
+		}
+		// The decoder rejected the packet, e.g. because it is corrupt.
+		p.decodeError(index, avutil.NewError(ret))
+	}
+	return p.receive(index, f, r)
+}
+
+//Report the frames output by the decoder of the stream until it needs more input.
+func (p *frameProbeStream) receive(index int, f *avutil.Frame, r *FrameProbeResult) error {
+	for {
+		ret := p.dec.Context().ReceiveFrame(f)
+		if ret == avutil.AVERROR_EAGAIN || ret == avutil.AVERROR_EOF {
+			return nil
+		}
+		if ret == avutil.AVERROR_ENOMEM {
+			return avutil.NewError(ret)
+		}
+		if ret < 0 {
+			p.decodeError(index, avutil.NewError(ret))
+			return nil
+		}
+		p.frame(f, r)
+		avutil.AvFrameUnref(f)
+	}
+}
+
+func (p *frameProbeStream) decodeError(index int, err error) {
+	p.report.DecodeErrors = append(p.report.DecodeErrors, DecodeError{Index: index, Error: err.Error()})
+}
+
+func (p *frameProbeStream) frame(f *avutil.Frame, r *FrameProbeResult) {
+	sr := p.report
+	index := sr.NbFrames
+	sr.NbFrames++
+
+	ts := f.BestEffortTimestamp()
+	if ts != avutil.AV_NOPTS_VALUE {
+		if p.lastPts != avutil.AV_NOPTS_VALUE && ts <= p.lastPts {
+			sr.Issues = append(sr.Issues, TimestampIssue{Kind: TimestampNonMonotonicFramePts, Index: index, Value: probeTs(ts), Previous: probeTs(p.lastPts)})
+		}
+		p.lastPts = ts
+	}
+
+	tb := p.st.TimeBase()
+	fr := FrameReport{
+		MediaType:               sr.CodecType,
+		StreamIndex:             sr.Index,
+		KeyFrame:                f.KeyFrame(),
+		Pts:                     probeTs(f.Pts()),
+		PtsTime:                 probeTime(f.Pts(), tb),
+		PktDts:                  probeTs(f.PktDts()),
+		PktDtsTime:              probeTime(f.PktDts(), tb),
+		BestEffortTimestamp:     probeTs(ts),
+		BestEffortTimestampTime: probeTime(ts, tb),
+		PktDuration:             f.PktDuration(),
+		PktDurationTime:         probeTime(f.PktDuration(), tb),
+	}
+	if pos := f.PktPos(); pos >= 0 {
+		fr.PktPos = strconv.FormatInt(pos, 10)
+	}
+	if size := f.PktSize(); size >= 0 {
+		fr.PktSize = strconv.Itoa(size)
+	}
+	switch p.st.CodecParameters().CodecType() {
+	case avutil.AVMEDIA_TYPE_VIDEO:
+		fr.Width = f.Width()
+		fr.Height = f.Height()
+		if name := C.av_get_pix_fmt_name((C.enum_AVPixelFormat)(f.Format())); name != nil {
+			fr.PixFmt = C.GoString(name)
+		}
+		fr.PictType = avutil.AvGetPictureTypeChar(f.PictType())
+		fr.InterlacedFrame = f.InterlacedFrame()
+		fr.TopFieldFirst = f.TopFieldFirst()
+		fr.RepeatPict = f.RepeatPict()
+	case avutil.AVMEDIA_TYPE_AUDIO:
+		if name := C.av_get_sample_fmt_name((C.enum_AVSampleFormat)(f.Format())); name != nil {
+			fr.SampleFmt = C.GoString(name)
+		}
+		fr.NbSamples = f.NbSamples()
+		fr.Channels = f.Channels()
+		if l := f.ChannelLayout(); l != 0 {
+			fr.ChannelLayout = avutil.AvGetChannelLayoutString(f.Channels(), l)
+		}
+	}
+	r.Frames = append(r.Frames, fr)
+}
@@ -0,0 +1,128 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package avformat
+
+//#cgo pkg-config: libavformat libavutil
+//#include <libavformat/avformat.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//Program is a Go copy of an AVProgram, e.g. a service of an MPEG-TS.
+type Program struct {
+	Id         int
+	ProgramNum int
+	PmtPid     int
+	PcrPid     int
+	//Indexes of the streams of the program.
+	StreamIndexes []int
+	Metadata      map[string]string
+}
+
+//Return a Go copy of the program.
+func (p *AvProgram) Program() Program {
+	g := Program{
+		Id:         int(p.id),
+		ProgramNum: int(p.program_num),
+		PmtPid:     int(p.pmt_pid),
+		PcrPid:     int(p.pcr_pid),
+		Metadata:   p.Metadata().Map(),
+	}
+	if n := int(p.nb_stream_indexes); n > 0 && p.stream_index != nil {
+		g.StreamIndexes = make([]int, 0, n)
+		for _, i := range (*[MAX_ARRAY_SIZE]C.uint)(unsafe.Pointer(p.stream_index))[:n:n] {
+			g.StreamIndexes = append(g.StreamIndexes, int(i))
+		}
+	}
+	return g
+}
+
+func (p *AvProgram) Id() int {
+	return int(p.id)
+}
+
+//Return the start time of the program in AV_TIME_BASE_Q.
+func (p *AvProgram) StartTime() int64 {
+	return int64(p.start_time)
+}
+
+//Return the end time of the program in AV_TIME_BASE_Q.
+func (p *AvProgram) EndTime() int64 {
+	return int64(p.end_time)
+}
+
+func (p *AvProgram) Metadata() *avutil.Dictionary {
+	return (*avutil.Dictionary)(unsafe.Pointer(p.metadata))
+}
+
+func (p *AvProgram) SetMetadata(key, value string) error {
+	return avutil.NewError(avutil.AvDictSet((**avutil.Dictionary)(unsafe.Pointer(&p.metadata)), key, value, 0))
+}
+
+func (p *AvProgram) SetProgramNum(n int) {
+	p.program_num = C.int(n)
+}
+
+func (p *AvProgram) SetPmtPid(pid int) {
+	p.pmt_pid = C.int(pid)
+}
+
+func (p *AvProgram) SetPcrPid(pid int) {
+	p.pcr_pid = C.int(pid)
+}
+
+//Return Go copies of the programs of the context.
+func (ctxt *Context) Programs() []Program {
+	cps := ctxt.avPrograms()
+	if len(cps) == 0 {
+		return nil
+	}
+	ps := make([]Program, 0, len(cps))
+	for _, p := range cps {
+		ps = append(ps, p.Program())
+	}
+	return ps
+}
+
+func (ctxt *Context) avPrograms() []*AvProgram {
+	n := int(ctxt.nb_programs)
+	if n == 0 || ctxt.programs == nil {
+		return nil
+	}
+	return (*[MAX_ARRAY_SIZE]*AvProgram)(unsafe.Pointer(ctxt.programs))[:n:n]
+}
+
+//Add the stream with index idx to the program with id progId.
+func (ctxt *Context) AvProgramAddStreamIndex(progId, idx int) {
+	C.av_program_add_stream_index((*C.struct_AVFormatContext)(ctxt), C.int(progId), C.uint(idx))
+}
+
+//Add a program to the context, or update the one with the same id, e.g. to mux several services in an MPEG-TS.
+//The streams of p.StreamIndexes must have been created. Programs must be added before the header is written.
+func (ctxt *Context) AddProgram(p Program) (*AvProgram, error) {
+	for _, i := range p.StreamIndexes {
+		if i < 0 || i >= int(ctxt.nb_streams) {
+			return nil, avutil.NewError(avutil.AVERROR_EINVAL)
+		}
+	}
+	cp := ctxt.AvNewProgram(p.Id)
+	if cp == nil {
+		return nil, avutil.NewError(avutil.AVERROR_ENOMEM)
+	}
+	cp.SetProgramNum(p.ProgramNum)
+	cp.SetPmtPid(p.PmtPid)
+	cp.SetPcrPid(p.PcrPid)
+	for k, v := range p.Metadata {
+		if err := cp.SetMetadata(k, v); err != nil {
+			return nil, err
+		}
+	}
+	for _, i := range p.StreamIndexes {
+		ctxt.AvProgramAddStreamIndex(p.Id, i)
+	}
+	return cp, nil
+}
@@ -7,6 +7,8 @@ package avformat
 //#include <libavformat/avformat.h>
 import "C"
 import (
+	"unsafe"
+
 	"github.com/asticode/goav/avutil"
 )
 
@@ -23,7 +25,7 @@ func (s *Stream) AvStreamGetRFrameRate() avutil.Rational {
 
 //struct CodecParserContext * av_stream_get_parser (const Stream *s)
 func (s *Stream) AvStreamGetParser() *CodecParserContext {
-	return (*CodecParserContext)(C.av_stream_get_parser((*C.struct_AVStream)(s)))
+	return (*CodecParserContext)(unsafe.Pointer(C.av_stream_get_parser((*C.struct_AVStream)(s))))
 }
 
 // //char * av_stream_get_recommended_encoder_configuration (const Stream *s)
@@ -30,8 +30,10 @@ func (avs *Stream) IndexEntries() *AvIndexEntry {
 	return (*AvIndexEntry)(unsafe.Pointer(avs.index_entries))
 }
 
-func (avs *Stream) AttachedPic() Packet {
-	return Packet(avs.attached_pic)
+//Return the packet holding the attached picture of a stream with the AV_DISPOSITION_ATTACHED_PIC disposition.
+//It is owned by the stream.
+func (avs *Stream) AttachedPic() *Packet {
+	return (*Packet)(unsafe.Pointer(&avs.attached_pic))
 }
 
 func (avs *Stream) SideData() *AvPacketSideData {
@@ -82,10 +84,6 @@ func (avs *Stream) CodecInfoNbFrames() int {
 	return int(avs.codec_info_nb_frames)
 }
 
-func (avs *Stream) Disposition() int {
-	return int(avs.disposition)
-}
-
 func (avs *Stream) EventFlags() int {
 	return int(avs.event_flags)
 }
@@ -210,6 +208,19 @@ func (avs *Stream) StartTime() int64 {
 	return int64(avs.start_time)
 }
 
+//Return v as a Timestamp in the time base of the stream.
+func (avs *Stream) Timestamp(v int64) avutil.Timestamp {
+	return avutil.NewTimestamp(v, avs.TimeBase())
+}
+
+func (avs *Stream) StartTimestamp() avutil.Timestamp {
+	return avs.Timestamp(avs.StartTime())
+}
+
+func (avs *Stream) DurationTimestamp() avutil.Timestamp {
+	return avs.Timestamp(avs.Duration())
+}
+
 func (avs *Stream) Parser() *CodecParserContext {
 	return (*CodecParserContext)(unsafe.Pointer(avs.parser))
 }
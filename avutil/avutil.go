@@ -23,6 +23,7 @@ type (
 	Options       C.struct_AVOptions
 	AvTree        C.struct_AVTree
 	Rational      C.struct_AVRational
+	Class         C.struct_AVClass
 	MediaType     C.enum_AVMediaType
 	AvPictureType C.enum_AVPictureType
 	PixelFormat   C.enum_AVPixelFormat
@@ -68,11 +69,15 @@ const (
 
 const (
 	AVERROR_EAGAIN    = -(C.EAGAIN)
+	AVERROR_EINVAL    = -(C.EINVAL)
 	AVERROR_EIO       = -(C.EIO)
+	AVERROR_ENOMEM    = -(C.ENOMEM)
+	AVERROR_ENOSYS    = -(C.ENOSYS)
 	AVERROR_EOF       = C.AVERROR_EOF
 	AVERROR_EPERM     = -(C.EPERM)
 	AVERROR_EPIPE     = -(C.EPIPE)
 	AVERROR_ETIMEDOUT = -(C.ETIMEDOUT)
+	AVERROR_EXTERNAL  = C.AVERROR_EXTERNAL
 )
 
 const (
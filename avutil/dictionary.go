@@ -13,6 +13,16 @@ type (
 	DictionaryEntry C.struct_AVDictionaryEntry
 )
 
+const (
+	AV_DICT_MATCH_CASE      = C.AV_DICT_MATCH_CASE
+	AV_DICT_IGNORE_SUFFIX   = C.AV_DICT_IGNORE_SUFFIX
+	AV_DICT_DONT_STRDUP_KEY = C.AV_DICT_DONT_STRDUP_KEY
+	AV_DICT_DONT_STRDUP_VAL = C.AV_DICT_DONT_STRDUP_VAL
+	AV_DICT_DONT_OVERWRITE  = C.AV_DICT_DONT_OVERWRITE
+	AV_DICT_APPEND          = C.AV_DICT_APPEND
+	AV_DICT_MULTIKEY        = C.AV_DICT_MULTIKEY
+)
+
 func AvDictSet(d **Dictionary, key, value string, flags int) int {
 	ck := C.CString(key)
 	defer C.free(unsafe.Pointer(ck))
@@ -48,3 +58,20 @@ func (e *DictionaryEntry) Key() string {
 func (e *DictionaryEntry) Value() string {
 	return C.GoString(e.value)
 }
+
+//Return a Go copy of the entries of the dictionary, or nil if it is empty.
+func (d *Dictionary) Map() map[string]string {
+	var m map[string]string
+	ck := C.CString("")
+	defer C.free(unsafe.Pointer(ck))
+	var e *C.struct_AVDictionaryEntry
+	for {
+		if e = C.av_dict_get((*C.struct_AVDictionary)(d), ck, e, C.AV_DICT_IGNORE_SUFFIX); e == nil {
+			return m
+		}
+		if m == nil {
+			m = make(map[string]string)
+		}
+		m[C.GoString(e.key)] = C.GoString(e.value)
+	}
+}
@@ -0,0 +1,16 @@
+package avutil
+
+//Error is a negative error code returned by an FFmpeg function.
+type Error int
+
+func (e Error) Error() string {
+	return AvStrerr(int(e))
+}
+
+//NewError returns an Error if ret is a negative FFmpeg return code, nil otherwise.
+func NewError(ret int) error {
+	if ret >= 0 {
+		return nil
+	}
+	return Error(ret)
+}
@@ -20,6 +20,16 @@ func (f *Frame) DataItem(idx int) **uint8 {
 	return (**uint8)(unsafe.Pointer(C.dataItem((*C.uint8_t)(unsafe.Pointer(&f.data)), C.int(idx))))
 }
 
+//Return the pointer to the data of plane i.
+func (f *Frame) DataAt(i int) *uint8 {
+	return (*uint8)(f.data[i])
+}
+
+//Return the line size of plane i.
+func (f *Frame) LinesizeAt(i int) int {
+	return int(f.linesize[i])
+}
+
 func (f *Frame) Linesize() int {
 	return int(*(*C.int)(unsafe.Pointer(&f.linesize)))
 }
@@ -68,6 +78,42 @@ func (f *Frame) SetPictType(t AvPictureType) {
 	f.pict_type = C.enum_AVPictureType(t)
 }
 
+func (f *Frame) KeyFrame() int {
+	return int(f.key_frame)
+}
+
+func (f *Frame) PictType() AvPictureType {
+	return AvPictureType(f.pict_type)
+}
+
+func (f *Frame) InterlacedFrame() int {
+	return int(f.interlaced_frame)
+}
+
+func (f *Frame) TopFieldFirst() int {
+	return int(f.top_field_first)
+}
+
+func (f *Frame) RepeatPict() int {
+	return int(f.repeat_pict)
+}
+
+func (f *Frame) BestEffortTimestamp() int64 {
+	return int64(f.best_effort_timestamp)
+}
+
+func (f *Frame) PktPos() int64 {
+	return int64(f.pkt_pos)
+}
+
+func (f *Frame) PktDuration() int64 {
+	return int64(f.pkt_duration)
+}
+
+func (f *Frame) PktSize() int {
+	return int(f.pkt_size)
+}
+
 func (f *Frame) Pts() int64 {
 	return int64(f.pts)
 }
@@ -88,6 +134,18 @@ func (f *Frame) SampleRate() int {
 	return int(f.sample_rate)
 }
 
+func (f *Frame) ChannelLayout() uint64 {
+	return uint64(f.channel_layout)
+}
+
+func (f *Frame) Channels() int {
+	return int(f.channels)
+}
+
+func (f *Frame) SetChannels(c int) {
+	f.channels = C.int(c)
+}
+
 func (f *Frame) SetChannelLayout(l uint64) {
 	f.channel_layout = C.uint64_t(l)
 }
@@ -96,6 +154,38 @@ func (f *Frame) SetSampleRate(r int) {
 	f.sample_rate = C.int(r)
 }
 
+func (f *Frame) ColorRange() AvColorRange {
+	return AvColorRange(f.color_range)
+}
+
+func (f *Frame) SetColorRange(r AvColorRange) {
+	f.color_range = C.enum_AVColorRange(r)
+}
+
+func (f *Frame) Colorspace() AvColorSpace {
+	return AvColorSpace(f.colorspace)
+}
+
+func (f *Frame) SetColorspace(c AvColorSpace) {
+	f.colorspace = C.enum_AVColorSpace(c)
+}
+
+func (f *Frame) ColorPrimaries() AvColorPrimaries {
+	return AvColorPrimaries(f.color_primaries)
+}
+
+func (f *Frame) SetColorPrimaries(p AvColorPrimaries) {
+	f.color_primaries = C.enum_AVColorPrimaries(p)
+}
+
+func (f *Frame) ColorTrc() AvColorTransferCharacteristic {
+	return AvColorTransferCharacteristic(f.color_trc)
+}
+
+func (f *Frame) SetColorTrc(t AvColorTransferCharacteristic) {
+	f.color_trc = C.enum_AVColorTransferCharacteristic(t)
+}
+
 // TODO Create getters and setters
 // https://ffmpeg.org/doxygen/4.0/structAVFrame.html
 /*
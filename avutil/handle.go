@@ -0,0 +1,200 @@
+package avutil
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// Leak describes a handle that has not been closed
+type Leak struct {
+	// Name is the kind of object the handle owns, e.g. "avutil.Frame"
+	Name string
+	// Stack is the stack trace of the allocation, only recorded when leak tracking is enabled
+	Stack   string
+	Created time.Time
+}
+
+// LeakHandler is called when the finalizer of a handle that has not been closed frees its object
+type LeakHandler func(l Leak)
+
+var handles = struct {
+	sync.Mutex
+	finalizers  bool
+	tracking    bool
+	leakHandler LeakHandler
+	nextID      uint64
+	live        map[uint64]Leak
+}{live: make(map[uint64]Leak)}
+
+func init() {
+	// GOAVDEBUG=leaks enables leak tracking from the start of the program
+	if os.Getenv("GOAVDEBUG") == "leaks" {
+		SetLeakTracking(true)
+	}
+}
+
+// SetFinalizers sets whether handles allocated from now on free their object when they are garbage collected
+// without having been closed. Finalizers are a safety net: a handle must be kept alive, e.g. with
+// runtime.KeepAlive(), for as long as the object it owns is used.
+func SetFinalizers(enabled bool) {
+	handles.Lock()
+	handles.finalizers = enabled
+	handles.Unlock()
+}
+
+// SetLeakTracking sets whether handles allocated from now on are tracked, with the stack trace of their allocation,
+// until they are closed. Tracking is meant for debugging: capturing stack traces is slow.
+func SetLeakTracking(enabled bool) {
+	handles.Lock()
+	handles.tracking = enabled
+	handles.Unlock()
+}
+
+// SetLeakHandler sets the function called when a finalizer frees the object of a handle that has not been closed
+func SetLeakHandler(h LeakHandler) {
+	handles.Lock()
+	handles.leakHandler = h
+	handles.Unlock()
+}
+
+// Leaks returns the tracked handles that have not been closed yet, oldest first
+func Leaks() []Leak {
+	handles.Lock()
+	ls := make([]Leak, 0, len(handles.live))
+	for _, l := range handles.live {
+		ls = append(ls, l)
+	}
+	handles.Unlock()
+	sort.Slice(ls, func(i, j int) bool { return ls[i].Created.Before(ls[j].Created) })
+	return ls
+}
+
+// Handle owns a C object and frees it exactly once, when it is closed or, if finalizers are enabled, garbage collected.
+// It is embedded by the typed handles of this and the other packages, e.g. FrameHandle, which add an accessor
+// to the object and a typed Release(). Accessors read the object through Pointer(), which is safe to call while
+// another goroutine closes the handle.
+type Handle struct {
+	mu   sync.Mutex
+	id   uint64
+	name string
+	ptr  unsafe.Pointer
+	free func(unsafe.Pointer)
+}
+
+// NewHandle returns a handle owning ptr, freed with free. name describes the kind of object, e.g. "avutil.Frame".
+func NewHandle(name string, ptr unsafe.Pointer, free func(unsafe.Pointer)) *Handle {
+	h := &Handle{name: name, ptr: ptr, free: free}
+	handles.Lock()
+	finalizers, tracking := handles.finalizers, handles.tracking
+	if tracking {
+		handles.nextID++
+		h.id = handles.nextID
+		handles.live[h.id] = Leak{Name: name, Stack: string(debug.Stack()), Created: time.Now()}
+	}
+	handles.Unlock()
+	if finalizers {
+		runtime.SetFinalizer(h, (*Handle).finalize)
+	}
+	return h
+}
+
+// Pointer returns the object owned by the handle, or nil once it is closed or released
+func (h *Handle) Pointer() unsafe.Pointer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.ptr
+}
+
+// Closed returns whether the handle no longer owns an object
+func (h *Handle) Closed() bool {
+	return h.Pointer() == nil
+}
+
+// Close frees the object owned by the handle. Closing a handle more than once is a no-op.
+func (h *Handle) Close() error {
+	if p := h.detach(); p != nil {
+		h.free(p)
+	}
+	return nil
+}
+
+// Release gives up the ownership of the object, which is returned and no longer freed by the handle
+func (h *Handle) Release() unsafe.Pointer {
+	return h.detach()
+}
+
+func (h *Handle) detach() unsafe.Pointer {
+	h.mu.Lock()
+	p := h.ptr
+	h.ptr = nil
+	h.mu.Unlock()
+	if p == nil {
+		return nil
+	}
+	runtime.SetFinalizer(h, nil)
+	h.untrack()
+	return p
+}
+
+func (h *Handle) untrack() (Leak, bool) {
+	if h.id == 0 {
+		return Leak{}, false
+	}
+	handles.Lock()
+	defer handles.Unlock()
+	l, ok := handles.live[h.id]
+	delete(handles.live, h.id)
+	return l, ok
+}
+
+func (h *Handle) finalize() {
+	h.mu.Lock()
+	p := h.ptr
+	h.ptr = nil
+	h.mu.Unlock()
+	if p == nil {
+		return
+	}
+	l, ok := h.untrack()
+	if !ok {
+		l = Leak{Name: h.name}
+	}
+	handles.Lock()
+	lh := handles.leakHandler
+	handles.Unlock()
+	if lh != nil {
+		lh(l)
+	}
+	h.free(p)
+}
+
+// FrameHandle owns a Frame allocated by NewFrame()
+type FrameHandle struct {
+	*Handle
+}
+
+// NewFrame allocates a Frame owned by the returned handle, which must be closed
+func NewFrame() (*FrameHandle, error) {
+	f := AvFrameAlloc()
+	if f == nil {
+		return nil, NewError(AVERROR_ENOMEM)
+	}
+	return &FrameHandle{NewHandle("avutil.Frame", unsafe.Pointer(f), func(p unsafe.Pointer) {
+		AvFrameFree((*Frame)(p))
+	})}, nil
+}
+
+// Frame returns the frame owned by the handle, or nil once it is closed or released
+func (f *FrameHandle) Frame() *Frame {
+	return (*Frame)(f.Pointer())
+}
+
+// Release gives up the ownership of the frame, which must then be freed with AvFrameFree()
+func (f *FrameHandle) Release() *Frame {
+	return (*Frame)(f.Handle.Release())
+}
@@ -1,21 +1,52 @@
 package avutil
 
 //#cgo pkg-config: libavutil
+//#include <stdint.h>
 //#include <libavutil/log.h>
+//#include <libavutil/mem.h>
 /*
-extern void goAvLogCustomCallback(int level, char* msg, char* parent);
+extern int goAvLogCustomCallback(void *avcl, int level, char *msg, char *itemName, char *className, void *parent, char *parentItemName);
 
 static inline void avLogCustomCallback(void *avcl, int level, const char *fmt, va_list vl)
 {
+	char buf[1024], *line = buf;
+	int printPrefix = 0, n, handled;
+	va_list vl2;
+	AVClass *avc = avcl ? *(AVClass **) avcl : NULL;
+	AVClass *pavc = NULL;
+	void *parent = NULL;
+
 	if (level > av_log_get_level()) return;
-	AVClass* avc = avcl ? *(AVClass **) avcl : NULL;
-	char parent[1024];
-	if (avc) {
-		sprintf(parent, "%p", avcl);
+
+	// The prefix is not printed: the item name and the parent are passed separately.
+	va_copy(vl2, vl);
+	n = av_log_format_line2(avcl, level, fmt, vl2, buf, sizeof(buf), &printPrefix);
+	va_end(vl2);
+	if (n < 0) return;
+	if (n >= (int)sizeof(buf)) {
+		line = av_malloc(n + 1);
+		if (!line) return;
+		printPrefix = 0;
+		va_copy(vl2, vl);
+		av_log_format_line2(avcl, level, fmt, vl2, line, n + 1, &printPrefix);
+		va_end(vl2);
 	}
-	char msg[1024];
-	vsprintf(msg, fmt, vl);
-	goAvLogCustomCallback(level, msg, parent);
+
+	if (avc && avc->parent_log_context_offset) {
+		AVClass **p = *(AVClass ***) ((uint8_t *) avcl + avc->parent_log_context_offset);
+		if (p && *p) {
+			parent = p;
+			pavc = *p;
+		}
+	}
+	handled = goAvLogCustomCallback(avcl, level, line,
+		avc && avc->item_name ? (char *) avc->item_name(avcl) : NULL,
+		avc ? (char *) avc->class_name : NULL,
+		parent,
+		pavc && pavc->item_name ? (char *) pavc->item_name(parent) : NULL);
+
+	if (line != buf) av_free(line);
+	if (!handled) av_log_default_callback(avcl, level, fmt, vl);
 }
 static inline void setAvLogCustomCallback()
 {
@@ -27,6 +58,12 @@ static inline void resetAvLogCallback()
 }
 */
 import "C"
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
 
 // Logging constants
 const (
@@ -38,8 +75,48 @@ const (
 	AV_LOG_INFO    = C.AV_LOG_INFO
 	AV_LOG_VERBOSE = C.AV_LOG_VERBOSE
 	AV_LOG_DEBUG   = C.AV_LOG_DEBUG
+	AV_LOG_TRACE   = C.AV_LOG_TRACE
+)
+
+// LogLevel is the level of a log message
+type LogLevel int
+
+// Log levels
+const (
+	LogLevelQuiet   LogLevel = AV_LOG_QUIET
+	LogLevelPanic   LogLevel = AV_LOG_PANIC
+	LogLevelFatal   LogLevel = AV_LOG_FATAL
+	LogLevelError   LogLevel = AV_LOG_ERROR
+	LogLevelWarning LogLevel = AV_LOG_WARNING
+	LogLevelInfo    LogLevel = AV_LOG_INFO
+	LogLevelVerbose LogLevel = AV_LOG_VERBOSE
+	LogLevelDebug   LogLevel = AV_LOG_DEBUG
+	LogLevelTrace   LogLevel = AV_LOG_TRACE
 )
 
+func (l LogLevel) String() string {
+	switch {
+	case l <= LogLevelQuiet:
+		return "quiet"
+	case l <= LogLevelPanic:
+		return "panic"
+	case l <= LogLevelFatal:
+		return "fatal"
+	case l <= LogLevelError:
+		return "error"
+	case l <= LogLevelWarning:
+		return "warning"
+	case l <= LogLevelInfo:
+		return "info"
+	case l <= LogLevelVerbose:
+		return "verbose"
+	case l <= LogLevelDebug:
+		return "debug"
+	default:
+		return "trace"
+	}
+}
+
 // AvLogGetLevel returns the current log level.
 func AvLogGetLevel() int {
 	return int(C.av_log_get_level())
@@ -50,26 +127,117 @@ func AvLogSetLevel(level int) {
 	C.av_log_set_level(C.int(level))
 }
 
+// LogMessage is a message logged by FFmpeg
+type LogMessage struct {
+	Level LogLevel
+	// Message is the formatted message, without the trailing newline
+	Message string
+	// Context is the context the message was logged for, or nil
+	Context unsafe.Pointer
+	// ClassName is the name of the AVClass of the context, e.g. "AVCodecContext"
+	ClassName string
+	// ItemName is the name of the context, e.g. "h264"
+	ItemName string
+	// Parent is the parent context of Context, or nil
+	Parent unsafe.Pointer
+	// ParentItemName is the name of the parent context
+	ParentItemName string
+
+	raw string
+}
+
+// Component returns the component the message was logged by, formatted as FFmpeg does, e.g. "h264 @ 0x..."
+func (m LogMessage) Component() string {
+	if m.Context == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s @ %p", m.ItemName, m.Context)
+}
+
+// ParentComponent returns the component of the parent context, e.g. "mov,mp4,m4a,3gp,3g2,mj2 @ 0x..."
+func (m LogMessage) ParentComponent() string {
+	if m.Parent == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s @ %p", m.ParentItemName, m.Parent)
+}
+
+// LogHandler handles messages logged by FFmpeg. It may be called concurrently from several threads.
+type LogHandler func(m LogMessage)
+
 // AvLogCallback represents a log callback
 type AvLogCallback func(level int, msg, parent string)
 
-var avLogCallback AvLogCallback
+var logHandler struct {
+	sync.RWMutex
+	h LogHandler
+}
+
+// SetLogHandler routes FFmpeg logs to h, or to the default callback if h is nil.
+// Messages logged by contexts registered with RegisterLogContext are routed to their own handler instead.
+func SetLogHandler(h LogHandler) {
+	logHandler.Lock()
+	logHandler.h = h
+	logHandler.Unlock()
+	updateLogCallback()
+}
+
+// Install the custom callback as long as a handler may receive messages
+func updateLogCallback() {
+	logHandler.RLock()
+	h := logHandler.h
+	logHandler.RUnlock()
+	logContexts.RLock()
+	n := len(logContexts.m)
+	logContexts.RUnlock()
+	if h == nil && n == 0 {
+		C.resetAvLogCallback()
+		return
+	}
+	C.setAvLogCustomCallback()
+}
 
 // AvLogSetCallback sets the log callback to a custom callback
 func AvLogSetCallback(c AvLogCallback) {
-	avLogCallback = c
-	C.setAvLogCustomCallback()
+	if c == nil {
+		SetLogHandler(nil)
+		return
+	}
+	SetLogHandler(func(m LogMessage) {
+		var parent string
+		if m.Context != nil {
+			parent = fmt.Sprintf("%p", m.Context)
+		}
+		c(int(m.Level), m.raw, parent)
+	})
 }
 
 //export goAvLogCustomCallback
-func goAvLogCustomCallback(level C.int, msg, parent *C.char) {
-	if avLogCallback == nil {
-		return
+func goAvLogCustomCallback(avcl unsafe.Pointer, level C.int, msg, itemName, className *C.char, parent unsafe.Pointer, parentItemName *C.char) C.int {
+	h := lookupLogHandler(avcl)
+	if h == nil {
+		logHandler.RLock()
+		h = logHandler.h
+		logHandler.RUnlock()
+	}
+	if h == nil {
+		return 0
 	}
-	avLogCallback(int(level), C.GoString(msg), C.GoString(parent))
+	raw := C.GoString(msg)
+	h(LogMessage{
+		Level:          LogLevel(level),
+		Message:        strings.TrimRight(raw, "\n"),
+		Context:        avcl,
+		ClassName:      C.GoString(className),
+		ItemName:       C.GoString(itemName),
+		Parent:         parent,
+		ParentItemName: C.GoString(parentItemName),
+		raw:            raw,
+	})
+	return 1
 }
 
 // AvLogResetCallback resets the log callback to the default callback
 func AvLogResetCallback() {
-	C.resetAvLogCallback()
+	SetLogHandler(nil)
 }
@@ -0,0 +1,103 @@
+package avutil
+
+//#cgo pkg-config: libavutil
+//#include <stdint.h>
+//#include <libavutil/log.h>
+/*
+static const char *goavLogClassName(void *avcl)
+{
+	AVClass *avc = avcl ? *(AVClass **) avcl : NULL;
+	return avc ? avc->class_name : NULL;
+}
+
+static void *goavLogParent(void *avcl)
+{
+	AVClass *avc = avcl ? *(AVClass **) avcl : NULL;
+	AVClass **p;
+	if (!avc || !avc->parent_log_context_offset)
+		return NULL;
+	p = *(AVClass ***) ((uint8_t *) avcl + avc->parent_log_context_offset);
+	return p && *p ? p : NULL;
+}
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+// Maximum number of ancestors walked to find the registered owner of a context
+const maxLogContextDepth = 16
+
+// LogParentFunc returns the context owning ctx, or nil
+type LogParentFunc func(ctx unsafe.Pointer) unsafe.Pointer
+
+var logContexts = struct {
+	sync.RWMutex
+	m       map[unsafe.Pointer]LogHandler
+	parents map[string]LogParentFunc
+}{
+	m:       make(map[unsafe.Pointer]LogHandler),
+	parents: make(map[string]LogParentFunc),
+}
+
+// RegisterLogContext routes messages logged by ctx, and by the contexts it is the parent of, to h.
+// ctx must point to a struct whose first member is an AVClass pointer. It must be unregistered when it is freed:
+// the free functions of this library (e.g. AvformatCloseInput, AvcodecFreeContext, AvfilterGraphFree, SwrFree,
+// SwsFreecontext), and thus the handles of the other packages, do it themselves.
+func RegisterLogContext(ctx unsafe.Pointer, h LogHandler) {
+	if ctx == nil {
+		return
+	}
+	if h == nil {
+		UnregisterLogContext(ctx)
+		return
+	}
+	logContexts.Lock()
+	logContexts.m[ctx] = h
+	logContexts.Unlock()
+	updateLogCallback()
+}
+
+// UnregisterLogContext stops routing messages logged by ctx to its own handler
+func UnregisterLogContext(ctx unsafe.Pointer) {
+	logContexts.Lock()
+	_, ok := logContexts.m[ctx]
+	delete(logContexts.m, ctx)
+	logContexts.Unlock()
+	if ok {
+		updateLogCallback()
+	}
+}
+
+// RegisterLogParentFunc sets how the owner of contexts of the AVClass named className is found,
+// for classes that do not set parent_log_context_offset (e.g. filters, owned by their graph).
+func RegisterLogParentFunc(className string, f LogParentFunc) {
+	logContexts.Lock()
+	logContexts.parents[className] = f
+	logContexts.Unlock()
+}
+
+// Return the handler of ctx or of its closest registered ancestor
+func lookupLogHandler(ctx unsafe.Pointer) LogHandler {
+	logContexts.RLock()
+	defer logContexts.RUnlock()
+	if len(logContexts.m) == 0 {
+		return nil
+	}
+	for i := 0; ctx != nil && i < maxLogContextDepth; i++ {
+		if h, ok := logContexts.m[ctx]; ok {
+			return h
+		}
+		if p := C.goavLogParent(ctx); p != nil {
+			ctx = p
+			continue
+		}
+		f, ok := logContexts.parents[C.GoString(C.goavLogClassName(ctx))]
+		if !ok {
+			return nil
+		}
+		ctx = f(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+//go:build go1.21
+// +build go1.21
+
+package avutil
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLevel returns the slog level matching the log level
+func (l LogLevel) SlogLevel() slog.Level {
+	switch {
+	case l <= LogLevelFatal:
+		return slog.LevelError + 4
+	case l <= LogLevelError:
+		return slog.LevelError
+	case l <= LogLevelWarning:
+		return slog.LevelWarn
+	case l <= LogLevelInfo:
+		return slog.LevelInfo
+	case l <= LogLevelVerbose:
+		return slog.LevelDebug
+	case l <= LogLevelDebug:
+		return slog.LevelDebug - 4
+	default:
+		return slog.LevelDebug - 8
+	}
+}
+
+// NewSlogHandler returns a LogHandler writing FFmpeg logs to l, tagged with the component that logged them
+func NewSlogHandler(l *slog.Logger) LogHandler {
+	return func(m LogMessage) {
+		lvl := m.Level.SlogLevel()
+		ctx := context.Background()
+		if !l.Enabled(ctx, lvl) {
+			return
+		}
+		attrs := make([]slog.Attr, 0, 3)
+		if m.Context != nil {
+			attrs = append(attrs, slog.String("component", m.Component()), slog.String("class", m.ClassName))
+		}
+		if m.Parent != nil {
+			attrs = append(attrs, slog.String("parent", m.ParentComponent()))
+		}
+		l.LogAttrs(ctx, lvl, m.Message, attrs...)
+	}
+}
+
+// SetSlogLogger routes FFmpeg logs to l
+func SetSlogLogger(l *slog.Logger) {
+	SetLogHandler(NewSlogHandler(l))
+}
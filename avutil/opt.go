@@ -0,0 +1,38 @@
+package avutil
+
+//#cgo pkg-config: libavutil
+//#include <libavutil/opt.h>
+import "C"
+
+const (
+	AV_OPT_TYPE_FLAGS          = int(C.AV_OPT_TYPE_FLAGS)
+	AV_OPT_TYPE_INT            = int(C.AV_OPT_TYPE_INT)
+	AV_OPT_TYPE_INT64          = int(C.AV_OPT_TYPE_INT64)
+	AV_OPT_TYPE_DOUBLE         = int(C.AV_OPT_TYPE_DOUBLE)
+	AV_OPT_TYPE_FLOAT          = int(C.AV_OPT_TYPE_FLOAT)
+	AV_OPT_TYPE_STRING         = int(C.AV_OPT_TYPE_STRING)
+	AV_OPT_TYPE_RATIONAL       = int(C.AV_OPT_TYPE_RATIONAL)
+	AV_OPT_TYPE_BINARY         = int(C.AV_OPT_TYPE_BINARY)
+	AV_OPT_TYPE_DICT           = int(C.AV_OPT_TYPE_DICT)
+	AV_OPT_TYPE_UINT64         = int(C.AV_OPT_TYPE_UINT64)
+	AV_OPT_TYPE_CONST          = int(C.AV_OPT_TYPE_CONST)
+	AV_OPT_TYPE_IMAGE_SIZE     = int(C.AV_OPT_TYPE_IMAGE_SIZE)
+	AV_OPT_TYPE_PIXEL_FMT      = int(C.AV_OPT_TYPE_PIXEL_FMT)
+	AV_OPT_TYPE_SAMPLE_FMT     = int(C.AV_OPT_TYPE_SAMPLE_FMT)
+	AV_OPT_TYPE_VIDEO_RATE     = int(C.AV_OPT_TYPE_VIDEO_RATE)
+	AV_OPT_TYPE_DURATION       = int(C.AV_OPT_TYPE_DURATION)
+	AV_OPT_TYPE_COLOR          = int(C.AV_OPT_TYPE_COLOR)
+	AV_OPT_TYPE_CHANNEL_LAYOUT = int(C.AV_OPT_TYPE_CHANNEL_LAYOUT)
+	AV_OPT_TYPE_BOOL           = int(C.AV_OPT_TYPE_BOOL)
+)
+
+const (
+	AV_OPT_FLAG_ENCODING_PARAM  = C.AV_OPT_FLAG_ENCODING_PARAM
+	AV_OPT_FLAG_DECODING_PARAM  = C.AV_OPT_FLAG_DECODING_PARAM
+	AV_OPT_FLAG_AUDIO_PARAM     = C.AV_OPT_FLAG_AUDIO_PARAM
+	AV_OPT_FLAG_VIDEO_PARAM     = C.AV_OPT_FLAG_VIDEO_PARAM
+	AV_OPT_FLAG_SUBTITLE_PARAM  = C.AV_OPT_FLAG_SUBTITLE_PARAM
+	AV_OPT_FLAG_EXPORT          = C.AV_OPT_FLAG_EXPORT
+	AV_OPT_FLAG_READONLY        = C.AV_OPT_FLAG_READONLY
+	AV_OPT_FLAG_FILTERING_PARAM = C.AV_OPT_FLAG_FILTERING_PARAM
+)
@@ -2,8 +2,16 @@ package avutil
 
 //#cgo pkg-config: libavutil
 //#include <libavutil/avutil.h>
+//#include <libavutil/pixfmt.h>
 import "C"
 
+type (
+	AvColorPrimaries              C.enum_AVColorPrimaries
+	AvColorRange                  C.enum_AVColorRange
+	AvColorSpace                  C.enum_AVColorSpace
+	AvColorTransferCharacteristic C.enum_AVColorTransferCharacteristic
+)
+
 const (
 	AV_PIX_FMT_BGR24    = C.AV_PIX_FMT_BGR24
 	AV_PIX_FMT_NONE     = C.AV_PIX_FMT_NONE
@@ -33,3 +41,26 @@ func PixelFormatFromString(i string) PixelFormat {
 		return -1
 	}
 }
+
+const (
+	AVCOL_RANGE_UNSPECIFIED = C.AVCOL_RANGE_UNSPECIFIED
+	AVCOL_RANGE_MPEG        = C.AVCOL_RANGE_MPEG
+	AVCOL_RANGE_JPEG        = C.AVCOL_RANGE_JPEG
+)
+
+const (
+	AVCOL_SPC_RGB                = C.AVCOL_SPC_RGB
+	AVCOL_SPC_BT709              = C.AVCOL_SPC_BT709
+	AVCOL_SPC_UNSPECIFIED        = C.AVCOL_SPC_UNSPECIFIED
+	AVCOL_SPC_FCC                = C.AVCOL_SPC_FCC
+	AVCOL_SPC_BT470BG            = C.AVCOL_SPC_BT470BG
+	AVCOL_SPC_SMPTE170M          = C.AVCOL_SPC_SMPTE170M
+	AVCOL_SPC_SMPTE240M          = C.AVCOL_SPC_SMPTE240M
+	AVCOL_SPC_YCGCO              = C.AVCOL_SPC_YCGCO
+	AVCOL_SPC_BT2020_NCL         = C.AVCOL_SPC_BT2020_NCL
+	AVCOL_SPC_BT2020_CL          = C.AVCOL_SPC_BT2020_CL
+	AVCOL_SPC_SMPTE2085          = C.AVCOL_SPC_SMPTE2085
+	AVCOL_SPC_CHROMA_DERIVED_NCL = C.AVCOL_SPC_CHROMA_DERIVED_NCL
+	AVCOL_SPC_CHROMA_DERIVED_CL  = C.AVCOL_SPC_CHROMA_DERIVED_CL
+	AVCOL_SPC_ICTCP              = C.AVCOL_SPC_ICTCP
+)
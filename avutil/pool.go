@@ -0,0 +1,230 @@
+package avutil
+
+//#cgo pkg-config: libavutil
+//#include <libavutil/frame.h>
+/*
+// Reset f like av_frame_unref() does, but keep its data buffers along with their geometry.
+// Frames with extended buffers are unreferenced entirely.
+static int goavFrameResetKeepBuffers(AVFrame *f)
+{
+	AVFrame *tmp;
+	int i;
+
+	if (f->nb_extended_buf || f->extended_data != f->data) {
+		av_frame_unref(f);
+		return 0;
+	}
+	tmp = av_frame_alloc();
+	if (!tmp)
+		return AVERROR(ENOMEM);
+	tmp->format         = f->format;
+	tmp->width          = f->width;
+	tmp->height         = f->height;
+	tmp->nb_samples     = f->nb_samples;
+	tmp->channel_layout = f->channel_layout;
+	tmp->channels       = f->channels;
+	for (i = 0; i < AV_NUM_DATA_POINTERS; i++) {
+		tmp->buf[i]      = f->buf[i];
+		tmp->data[i]     = f->data[i];
+		tmp->linesize[i] = f->linesize[i];
+		f->buf[i]        = NULL;
+	}
+	tmp->extended_data = tmp->data;
+	av_frame_unref(f);
+	av_frame_move_ref(f, tmp);
+	av_frame_free(&tmp);
+	return 0;
+}
+*/
+import "C"
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// PoolStats holds the metrics of a pool
+type PoolStats struct {
+	// Hits is the number of Get() calls served by an idle object
+	Hits uint64
+	// Misses is the number of Get() calls that had to allocate an object
+	Misses uint64
+	// Discards is the number of Put() calls that freed the object because the pool was full
+	Discards uint64
+	// Idle is the number of objects currently held by the pool
+	Idle int
+}
+
+// PoolCounters counts the hits, misses and discards of a pool. It is safe for concurrent use.
+type PoolCounters struct {
+	hits     uint64
+	misses   uint64
+	discards uint64
+}
+
+// Hit counts a Get() call served by an idle object
+func (c *PoolCounters) Hit() {
+	atomic.AddUint64(&c.hits, 1)
+}
+
+// Miss counts a Get() call that had to allocate an object
+func (c *PoolCounters) Miss() {
+	atomic.AddUint64(&c.misses, 1)
+}
+
+// Discard counts a Put() call that freed the object
+func (c *PoolCounters) Discard() {
+	atomic.AddUint64(&c.discards, 1)
+}
+
+// Stats returns the counters along with the number of idle objects
+func (c *PoolCounters) Stats(idle int) PoolStats {
+	return PoolStats{
+		Hits:     atomic.LoadUint64(&c.hits),
+		Misses:   atomic.LoadUint64(&c.misses),
+		Discards: atomic.LoadUint64(&c.discards),
+		Idle:     idle,
+	}
+}
+
+// FramePool hands out reusable frames. Frames are unreferenced when they are put back, so that the pool never
+// holds on to the data of a frame, except for the buffers of sized pools, which are reused by the next Get().
+type FramePool struct {
+	// counters is accessed atomically and must stay the first field to be 64-bit aligned on 32-bit platforms
+	counters PoolCounters
+	mu       sync.Mutex
+	frames   []*Frame
+	capacity int
+	setup    func(f *Frame) error
+	// sized returns whether f still has the geometry set up by setup
+	sized func(f *Frame) bool
+}
+
+// NewFramePool returns a pool keeping at most capacity idle frames, all of them allocated upfront
+func NewFramePool(capacity int) (*FramePool, error) {
+	return newFramePool(capacity, nil, nil)
+}
+
+// NewVideoFramePool returns a pool of frames holding buffers for width x height images in format,
+// keeping at most capacity idle frames. align is passed to AvFrameGetBuffer().
+func NewVideoFramePool(capacity, width, height int, format PixelFormat, align int) (*FramePool, error) {
+	return newFramePool(capacity, func(f *Frame) error {
+		f.SetWidth(width)
+		f.SetHeight(height)
+		f.SetFormat(int(format))
+		return NewError(AvFrameGetBuffer(f, align))
+	}, func(f *Frame) bool {
+		return f.Width() == width && f.Height() == height && f.Format() == int(format)
+	})
+}
+
+// NewAudioFramePool returns a pool of frames holding buffers for nbSamples samples in sampleFormat and channelLayout,
+// keeping at most capacity idle frames. align is passed to AvFrameGetBuffer().
+func NewAudioFramePool(capacity, nbSamples int, sampleFormat AvSampleFormat, channelLayout uint64, align int) (*FramePool, error) {
+	return newFramePool(capacity, func(f *Frame) error {
+		f.SetNbSamples(nbSamples)
+		f.SetFormat(int(sampleFormat))
+		f.SetChannelLayout(channelLayout)
+		return NewError(AvFrameGetBuffer(f, align))
+	}, func(f *Frame) bool {
+		return f.NbSamples() == nbSamples && f.Format() == int(sampleFormat) && f.ChannelLayout() == channelLayout
+	})
+}
+
+func newFramePool(capacity int, setup func(f *Frame) error, sized func(f *Frame) bool) (*FramePool, error) {
+	p := &FramePool{capacity: capacity, setup: setup, sized: sized, frames: make([]*Frame, 0, capacity)}
+	for i := 0; i < capacity; i++ {
+		f, err := p.alloc()
+		if err != nil {
+			p.Free()
+			return nil, err
+		}
+		p.frames = append(p.frames, f)
+	}
+	return p, nil
+}
+
+func (p *FramePool) alloc() (*Frame, error) {
+	f := AvFrameAlloc()
+	if f == nil {
+		return nil, NewError(AVERROR_ENOMEM)
+	}
+	if p.setup != nil {
+		if err := p.setup(f); err != nil {
+			AvFrameFree(f)
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// Get returns an idle frame, or a newly allocated one if the pool is empty.
+// Frames of sized pools always hold writable buffers, which are only reallocated if they are still referenced
+// elsewhere or if the frame was resized while it was used.
+func (p *FramePool) Get() (*Frame, error) {
+	p.mu.Lock()
+	var f *Frame
+	if n := len(p.frames); n > 0 {
+		f = p.frames[n-1]
+		p.frames = p.frames[:n-1]
+	}
+	p.mu.Unlock()
+
+	if f == nil {
+		p.counters.Miss()
+		return p.alloc()
+	}
+	p.counters.Hit()
+	if p.setup == nil {
+		return f, nil
+	}
+	if f.DataAt(0) != nil && AvFrameIsWritable(f) == 0 {
+		AvFrameUnref(f)
+	}
+	if f.DataAt(0) == nil {
+		if err := p.setup(f); err != nil {
+			AvFrameFree(f)
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// Put unreferences f and gives it back to the pool, or frees it if the pool is full.
+// Frames of sized pools keep their buffers if they still have the geometry of the pool.
+func (p *FramePool) Put(f *Frame) {
+	if f == nil {
+		return
+	}
+	if p.sized == nil || !p.sized(f) || C.goavFrameResetKeepBuffers((*C.struct_AVFrame)(f)) < 0 {
+		AvFrameUnref(f)
+	}
+	p.mu.Lock()
+	if len(p.frames) < p.capacity {
+		p.frames = append(p.frames, f)
+		f = nil
+	}
+	p.mu.Unlock()
+	if f != nil {
+		p.counters.Discard()
+		AvFrameFree(f)
+	}
+}
+
+// Stats returns the metrics of the pool
+func (p *FramePool) Stats() PoolStats {
+	p.mu.Lock()
+	idle := len(p.frames)
+	p.mu.Unlock()
+	return p.counters.Stats(idle)
+}
+
+// Free frees the idle frames. Frames put back afterwards are still kept, up to the capacity of the pool.
+func (p *FramePool) Free() {
+	p.mu.Lock()
+	frames := p.frames
+	p.frames = nil
+	p.mu.Unlock()
+	for _, f := range frames {
+		AvFrameFree(f)
+	}
+}
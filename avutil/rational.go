@@ -0,0 +1,67 @@
+package avutil
+
+//#cgo pkg-config: libavutil
+//#include <libavutil/rational.h>
+//#include <libavutil/mathematics.h>
+import "C"
+
+func (r Rational) c() C.struct_AVRational {
+	return (C.struct_AVRational)(r)
+}
+
+//Multiply two rationals.
+func AvMulQ(b, c Rational) Rational {
+	return Rational(C.av_mul_q(b.c(), c.c()))
+}
+
+//Divide one rational by another.
+func AvDivQ(b, c Rational) Rational {
+	return Rational(C.av_div_q(b.c(), c.c()))
+}
+
+//Add two rationals.
+func AvAddQ(b, c Rational) Rational {
+	return Rational(C.av_add_q(b.c(), c.c()))
+}
+
+//Subtract one rational from another.
+func AvSubQ(b, c Rational) Rational {
+	return Rational(C.av_sub_q(b.c(), c.c()))
+}
+
+//Compare two rationals: 0 if a == b, 1 if a > b, -1 if a < b and INT_MIN if one of the values is of the form 0/0.
+func AvCmpQ(a, b Rational) int {
+	return int(C.av_cmp_q(a.c(), b.c()))
+}
+
+//Invert a rational.
+func AvInvQ(q Rational) Rational {
+	return Rational(C.av_inv_q(q.c()))
+}
+
+//Reduce a fraction, with numerator and denominator not exceeding max.
+//It returns the reduced rational and whether the reduction is exact.
+func AvReduce(num, den, max int64) (Rational, bool) {
+	var n, d C.int
+	exact := C.av_reduce(&n, &d, C.int64_t(num), C.int64_t(den), C.int64_t(max))
+	return NewRational(int(n), int(d)), exact != 0
+}
+
+//Convert a double precision floating point number to a rational, with numerator and denominator not exceeding max.
+func AvD2Q(d float64, max int) Rational {
+	return Rational(C.av_d2q(C.double(d), C.int(max)))
+}
+
+//Compare two timestamps each in its own time base: -1 if tsA is before tsB, 1 if after, 0 if they represent the same position.
+func AvCompareTs(tsA int64, tbA Rational, tsB int64, tbB Rational) int {
+	return int(C.av_compare_ts(C.int64_t(tsA), tbA.c(), C.int64_t(tsB), tbB.c()))
+}
+
+//Rescale a timestamp while preserving known durations, see av_rescale_delta().
+//lastOut holds the state between calls and must be initialized to AV_NOPTS_VALUE.
+func AvRescaleDelta(inTb Rational, inTs int64, fsTb Rational, duration int, lastOut *int64, outTb Rational) int64 {
+	l := C.int64_t(*lastOut)
+	ret := C.av_rescale_delta(inTb.c(), C.int64_t(inTs), fsTb.c(), C.int(duration), &l, outTb.c())
+	*lastOut = int64(l)
+	return int64(ret)
+}
@@ -5,29 +5,31 @@ package avutil
 import "C"
 import "unsafe"
 
+type AvSampleFormat C.enum_AVSampleFormat
+
 const (
-	AV_SAMPLE_FMT_NONE = int(C.AV_SAMPLE_FMT_NONE)
-	AV_SAMPLE_FMT_U8   = int(C.AV_SAMPLE_FMT_U8)
-	AV_SAMPLE_FMT_S16  = int(C.AV_SAMPLE_FMT_S16)
-	AV_SAMPLE_FMT_S32  = int(C.AV_SAMPLE_FMT_S32)
-	AV_SAMPLE_FMT_FLT  = int(C.AV_SAMPLE_FMT_FLT)
-	AV_SAMPLE_FMT_DBL  = int(C.AV_SAMPLE_FMT_DBL)
+	AV_SAMPLE_FMT_NONE AvSampleFormat = C.AV_SAMPLE_FMT_NONE
+	AV_SAMPLE_FMT_U8   AvSampleFormat = C.AV_SAMPLE_FMT_U8
+	AV_SAMPLE_FMT_S16  AvSampleFormat = C.AV_SAMPLE_FMT_S16
+	AV_SAMPLE_FMT_S32  AvSampleFormat = C.AV_SAMPLE_FMT_S32
+	AV_SAMPLE_FMT_FLT  AvSampleFormat = C.AV_SAMPLE_FMT_FLT
+	AV_SAMPLE_FMT_DBL  AvSampleFormat = C.AV_SAMPLE_FMT_DBL
 
-	AV_SAMPLE_FMT_U8P  = int(C.AV_SAMPLE_FMT_U8P)
-	AV_SAMPLE_FMT_S16P = int(C.AV_SAMPLE_FMT_S16P)
-	AV_SAMPLE_FMT_S32P = int(C.AV_SAMPLE_FMT_S32P)
-	AV_SAMPLE_FMT_FLTP = int(C.AV_SAMPLE_FMT_FLTP)
-	AV_SAMPLE_FMT_DBLP = int(C.AV_SAMPLE_FMT_DBLP)
-	AV_SAMPLE_FMT_S64  = int(C.AV_SAMPLE_FMT_S64)
-	AV_SAMPLE_FMT_S64P = int(C.AV_SAMPLE_FMT_S64P)
+	AV_SAMPLE_FMT_U8P  AvSampleFormat = C.AV_SAMPLE_FMT_U8P
+	AV_SAMPLE_FMT_S16P AvSampleFormat = C.AV_SAMPLE_FMT_S16P
+	AV_SAMPLE_FMT_S32P AvSampleFormat = C.AV_SAMPLE_FMT_S32P
+	AV_SAMPLE_FMT_FLTP AvSampleFormat = C.AV_SAMPLE_FMT_FLTP
+	AV_SAMPLE_FMT_DBLP AvSampleFormat = C.AV_SAMPLE_FMT_DBLP
+	AV_SAMPLE_FMT_S64  AvSampleFormat = C.AV_SAMPLE_FMT_S64
+	AV_SAMPLE_FMT_S64P AvSampleFormat = C.AV_SAMPLE_FMT_S64P
 
-	AV_SAMPLE_FMT_NB = int(C.AV_SAMPLE_FMT_NB)
+	AV_SAMPLE_FMT_NB AvSampleFormat = C.AV_SAMPLE_FMT_NB
 )
 
-func AvGetSampleFmtName(sampleFmt int) string {
+func AvGetSampleFmtName(sampleFmt AvSampleFormat) string {
 	return C.GoString(C.av_get_sample_fmt_name((C.enum_AVSampleFormat)(sampleFmt)))
 }
 
-func AvSamplesAlloc(data **uint8, linesize *int, nbChannels, nbSamples, sampleFmt, align int) int {
+func AvSamplesAlloc(data **uint8, linesize *int, nbChannels, nbSamples int, sampleFmt AvSampleFormat, align int) int {
 	return int(C.av_samples_alloc((**C.uint8_t)(unsafe.Pointer(data)), (*C.int)(unsafe.Pointer(linesize)), C.int(nbChannels), C.int(nbSamples), (C.enum_AVSampleFormat)(sampleFmt), C.int(align)))
 }
@@ -0,0 +1,56 @@
+package avutil
+
+import (
+	"strconv"
+	"time"
+)
+
+var timeDurationQ = NewRational(1, int(time.Second))
+
+//Timestamp is a value expressed in a time base.
+type Timestamp struct {
+	Value    int64
+	TimeBase Rational
+}
+
+func NewTimestamp(v int64, tb Rational) Timestamp {
+	return Timestamp{Value: v, TimeBase: tb}
+}
+
+//Convert d to a Timestamp in tb.
+func TimestampFromDuration(d time.Duration, tb Rational) Timestamp {
+	return Timestamp{Value: AvRescaleQ(int64(d), timeDurationQ, tb), TimeBase: tb}
+}
+
+//Return whether the timestamp is undefined.
+func (t Timestamp) IsNoPTS() bool {
+	return t.Value == AV_NOPTS_VALUE
+}
+
+//Return the timestamp as a time.Duration, or 0 if it is undefined.
+func (t Timestamp) Duration() time.Duration {
+	if t.IsNoPTS() {
+		return 0
+	}
+	return time.Duration(AvRescaleQ(t.Value, t.TimeBase, timeDurationQ))
+}
+
+//Return the timestamp expressed in tb, rounding to the nearest value. Undefined timestamps stay undefined.
+func (t Timestamp) Rescale(tb Rational) Timestamp {
+	if t.IsNoPTS() {
+		return Timestamp{Value: AV_NOPTS_VALUE, TimeBase: tb}
+	}
+	return Timestamp{Value: AvRescaleQRnd(t.Value, t.TimeBase, tb, AV_ROUND_NEAR_INF|AV_ROUND_PASS_MINMAX), TimeBase: tb}
+}
+
+//Compare t and u: -1 if t is before u, 1 if after and 0 if they represent the same position.
+func (t Timestamp) Compare(u Timestamp) int {
+	return AvCompareTs(t.Value, t.TimeBase, u.Value, u.TimeBase)
+}
+
+func (t Timestamp) String() string {
+	if t.IsNoPTS() {
+		return "NOPTS"
+	}
+	return strconv.FormatInt(t.Value, 10) + "@" + t.TimeBase.String()
+}
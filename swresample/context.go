@@ -10,6 +10,8 @@ package swresample
 import "C"
 import (
 	"unsafe"
+
+	"github.com/asticode/goav/avutil"
 )
 
 //Initialize context after user parameters have been set.
@@ -29,7 +31,10 @@ func (s *Context) SwrAllocSetOpts(ocl int64, osf AvSampleFormat, osr int, icl in
 
 //Context destructor functions. Free the given Context and set the pointer to NULL.
 func (s *Context) SwrFree() {
+	p := unsafe.Pointer(s)
+	s.setChannelMapping(nil)
 	C.swr_free((**C.struct_SwrContext)(unsafe.Pointer(&s)))
+	avutil.UnregisterLogContext(p)
 }
 
 //Closes the context so that swr_is_initialized() returns 0.
@@ -81,10 +86,10 @@ func (s *Context) SwrGetDelay(b int64) int64 {
 
 //Frame based API. Convert the samples in the input Frame and write them to the output Frame.
 func (s *Context) SwrConvertFrame(o, i *Frame) int {
-	return int(C.swr_convert_frame((*C.struct_SwrContext)(s), (*C.struct_AVFrame)(o), (*C.struct_AVFrame)(i)))
+	return int(C.swr_convert_frame((*C.struct_SwrContext)(s), (*C.struct_AVFrame)(unsafe.Pointer(o)), (*C.struct_AVFrame)(unsafe.Pointer(i))))
 }
 
 //Configure or reconfigure the Context using the information provided by the AvFrames.
 func (s *Context) SwrConfigFrame(o, i *Frame) int {
-	return int(C.swr_config_frame((*C.struct_SwrContext)(s), (*C.struct_AVFrame)(o), (*C.struct_AVFrame)(i)))
+	return int(C.swr_config_frame((*C.struct_SwrContext)(s), (*C.struct_AVFrame)(unsafe.Pointer(o)), (*C.struct_AVFrame)(unsafe.Pointer(i))))
 }
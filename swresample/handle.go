@@ -0,0 +1,40 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swresample
+
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//ContextHandle owns a Context allocated by NewContext().
+type ContextHandle struct {
+	*avutil.Handle
+}
+
+//Allocate a Context owned by the returned handle, which must be closed.
+func NewContext() (*ContextHandle, error) {
+	s := SwrAlloc()
+	if s == nil {
+		return nil, avutil.NewError(avutil.AVERROR_ENOMEM)
+	}
+	return newContextHandle(s), nil
+}
+
+func newContextHandle(s *Context) *ContextHandle {
+	return &ContextHandle{avutil.NewHandle("swresample.Context", unsafe.Pointer(s), func(p unsafe.Pointer) {
+		(*Context)(p).SwrFree()
+	})}
+}
+
+//Return the context owned by the handle, or nil once it is closed or released.
+func (s *ContextHandle) Context() *Context {
+	return (*Context)(s.Pointer())
+}
+
+//Give up the ownership of the context, which must then be freed with SwrFree().
+func (s *ContextHandle) Release() *Context {
+	return (*Context)(s.Handle.Release())
+}
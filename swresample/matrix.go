@@ -0,0 +1,121 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swresample
+
+/*
+	#cgo pkg-config: libswresample libavutil
+	#include <libswresample/swresample.h>
+	#include <libavutil/channel_layout.h>
+	#include <libavutil/mem.h>
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+const (
+	AV_MATRIX_ENCODING_NONE           = int(C.AV_MATRIX_ENCODING_NONE)
+	AV_MATRIX_ENCODING_DOLBY          = int(C.AV_MATRIX_ENCODING_DOLBY)
+	AV_MATRIX_ENCODING_DPLII          = int(C.AV_MATRIX_ENCODING_DPLII)
+	AV_MATRIX_ENCODING_DPLIIX         = int(C.AV_MATRIX_ENCODING_DPLIIX)
+	AV_MATRIX_ENCODING_DPLIIZ         = int(C.AV_MATRIX_ENCODING_DPLIIZ)
+	AV_MATRIX_ENCODING_DOLBYEX        = int(C.AV_MATRIX_ENCODING_DOLBYEX)
+	AV_MATRIX_ENCODING_DOLBYHEADPHONE = int(C.AV_MATRIX_ENCODING_DOLBYHEADPHONE)
+)
+
+const maxChannels = 64
+
+//swr_set_channel_mapping() keeps a pointer to the mapping, so it is stored in C memory owned by the package until the context is freed.
+var channelMappings = struct {
+	sync.Mutex
+	m map[*Context]unsafe.Pointer
+}{m: make(map[*Context]unsafe.Pointer)}
+
+//Set a customized remix matrix, indexed as m[output channel][input channel].
+//Must be called before SwrInit().
+func (s *Context) SetMatrix(m [][]float64) error {
+	if len(m) == 0 {
+		return errors.New("swresample: empty matrix")
+	}
+	stride := len(m[0])
+	cm := make([]C.double, len(m)*stride)
+	for o, row := range m {
+		if len(row) != stride {
+			return fmt.Errorf("swresample: matrix row %d has %d coefficients, expected %d", o, len(row), stride)
+		}
+		for i, v := range row {
+			cm[o*stride+i] = C.double(v)
+		}
+	}
+	return avutil.NewError(int(C.swr_set_matrix((*C.struct_SwrContext)(s), &cm[0], C.int(stride))))
+}
+
+//Set a customized input channel mapping: m[i] is the index of the input channel used as channel i, or -1 to mute it.
+//Must be called before SwrInit().
+func (s *Context) SetChannelMapping(m []int) error {
+	if len(m) > maxChannels {
+		return fmt.Errorf("swresample: channel mapping has more than %d channels", maxChannels)
+	}
+	var cm unsafe.Pointer
+	if len(m) > 0 {
+		cm = C.av_malloc_array(C.size_t(len(m)), C.size_t(unsafe.Sizeof(C.int(0))))
+		if cm == nil {
+			return errors.New("swresample: allocating channel mapping failed")
+		}
+		cs := (*[maxChannels]C.int)(cm)[:len(m):len(m)]
+		for i, v := range m {
+			cs[i] = C.int(v)
+		}
+	}
+	if err := avutil.NewError(int(C.swr_set_channel_mapping((*C.struct_SwrContext)(s), (*C.int)(cm)))); err != nil {
+		C.av_free(cm)
+		return err
+	}
+	s.setChannelMapping(cm)
+	return nil
+}
+
+func (s *Context) setChannelMapping(cm unsafe.Pointer) {
+	channelMappings.Lock()
+	defer channelMappings.Unlock()
+	if p, ok := channelMappings.m[s]; ok {
+		C.av_free(p)
+		delete(channelMappings.m, s)
+	}
+	if cm != nil {
+		channelMappings.m[s] = cm
+	}
+}
+
+//Generate a channel mixing matrix, indexed as m[output channel][input channel].
+//centerMixLevel, surroundMixLevel and lfeMixLevel are the mix levels of the respective channels,
+//maxval is the maximum value of a coefficient (or 0 for no limit) and rematrixVolume the rematrixing volume.
+func BuildMatrix(inLayout, outLayout uint64, centerMixLevel, surroundMixLevel, lfeMixLevel, maxval, rematrixVolume float64, matrixEncoding int) ([][]float64, error) {
+	nbIn := avutil.AvGetChannelLayoutNbChannels(inLayout)
+	nbOut := avutil.AvGetChannelLayoutNbChannels(outLayout)
+	if nbIn <= 0 || nbOut <= 0 {
+		return nil, errors.New("swresample: invalid channel layout")
+	}
+	cm := make([]C.double, nbIn*nbOut)
+	ret := C.swr_build_matrix(C.uint64_t(inLayout), C.uint64_t(outLayout),
+		C.double(centerMixLevel), C.double(surroundMixLevel), C.double(lfeMixLevel),
+		C.double(maxval), C.double(rematrixVolume), &cm[0], C.int(nbIn),
+		C.enum_AVMatrixEncoding(matrixEncoding), nil)
+	if err := avutil.NewError(int(ret)); err != nil {
+		return nil, err
+	}
+	m := make([][]float64, nbOut)
+	for o := range m {
+		m[o] = make([]float64, nbIn)
+		for i := range m[o] {
+			m[o][i] = float64(cm[o*nbIn+i])
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,158 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swresample
+
+/*
+	#cgo pkg-config: libswresample libavutil
+	#include <libswresample/swresample.h>
+	#include <libavutil/frame.h>
+*/
+import "C"
+import (
+	"errors"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//AudioFormat describes the layout, sample format and rate of audio samples.
+type AudioFormat struct {
+	ChannelLayout uint64
+	SampleFormat  AvSampleFormat
+	SampleRate    int
+}
+
+//Return the audio format of f.
+func AudioFormatFromFrame(f *avutil.Frame) AudioFormat {
+	return AudioFormat{
+		ChannelLayout: f.ChannelLayout(),
+		SampleFormat:  AvSampleFormat(f.Format()),
+		SampleRate:    f.SampleRate(),
+	}
+}
+
+//Resampler converts audio frames from one AudioFormat to another.
+type Resampler struct {
+	h   *ContextHandle
+	in  AudioFormat
+	out AudioFormat
+}
+
+//ResamplerOption configures the Context of a Resampler before it is initialized.
+type ResamplerOption func(*Context) error
+
+//Use a customized remix matrix, indexed as m[output channel][input channel].
+func WithMatrix(m [][]float64) ResamplerOption {
+	return func(s *Context) error {
+		return s.SetMatrix(m)
+	}
+}
+
+//Use a customized input channel mapping.
+func WithChannelMapping(m []int) ResamplerOption {
+	return func(s *Context) error {
+		return s.SetChannelMapping(m)
+	}
+}
+
+//Allocate and initialize a Resampler converting audio from in to out.
+func NewResampler(in, out AudioFormat, opts ...ResamplerOption) (*Resampler, error) {
+	ctx := (*Context)(C.swr_alloc_set_opts(nil,
+		C.int64_t(out.ChannelLayout), C.enum_AVSampleFormat(out.SampleFormat), C.int(out.SampleRate),
+		C.int64_t(in.ChannelLayout), C.enum_AVSampleFormat(in.SampleFormat), C.int(in.SampleRate),
+		0, nil))
+	if ctx == nil {
+		return nil, errors.New("swresample: allocating context failed")
+	}
+	h := newContextHandle(ctx)
+	for _, o := range opts {
+		if err := o(ctx); err != nil {
+			h.Close()
+			return nil, err
+		}
+	}
+	if err := avutil.NewError(ctx.SwrInit()); err != nil {
+		h.Close()
+		return nil, err
+	}
+	return &Resampler{h: h, in: in, out: out}, nil
+}
+
+//Return the underlying Context, or nil once the resampler is freed.
+func (r *Resampler) Context() *Context {
+	if r.h == nil {
+		return nil
+	}
+	return r.h.Context()
+}
+
+func (r *Resampler) InputFormat() AudioFormat {
+	return r.in
+}
+
+func (r *Resampler) OutputFormat() AudioFormat {
+	return r.out
+}
+
+//Return the delay of the resampler, in output samples.
+func (r *Resampler) Delay() int64 {
+	return r.Context().SwrGetDelay(int64(r.out.SampleRate))
+}
+
+//Convert the samples of in and return them in a newly allocated frame, which must be freed with avutil.AvFrameFree().
+//The returned frame may hold fewer samples than in, the remaining ones being buffered until the next call or Flush().
+func (r *Resampler) Convert(in *avutil.Frame) (*avutil.Frame, error) {
+	return r.convert(in, 0)
+}
+
+//Drain the samples buffered by the resampler at the end of the stream.
+//It returns nil once no sample is left.
+func (r *Resampler) Flush() (*avutil.Frame, error) {
+	d := r.Delay()
+	if d <= 0 {
+		return nil, nil
+	}
+	f, err := r.convert(nil, int(d))
+	if err != nil || f == nil || f.NbSamples() > 0 {
+		return f, err
+	}
+	avutil.AvFrameFree(f)
+	return nil, nil
+}
+
+//Convert in, or drain the resampler if in is nil. If nbSamples is 0, swresample computes the output frame size.
+func (r *Resampler) convert(in *avutil.Frame, nbSamples int) (*avutil.Frame, error) {
+	out := avutil.AvFrameAlloc()
+	if out == nil {
+		return nil, errors.New("swresample: allocating frame failed")
+	}
+	out.SetChannelLayout(r.out.ChannelLayout)
+	out.SetFormat(int(r.out.SampleFormat))
+	out.SetSampleRate(r.out.SampleRate)
+	if nbSamples > 0 {
+		out.SetNbSamples(nbSamples)
+		if err := avutil.NewError(avutil.AvFrameGetBuffer(out, 0)); err != nil {
+			avutil.AvFrameFree(out)
+			return nil, err
+		}
+	}
+	if err := avutil.NewError(r.Context().SwrConvertFrame(out, in)); err != nil {
+		avutil.AvFrameFree(out)
+		return nil, err
+	}
+	return out, nil
+}
+
+//Free the underlying Context.
+func (r *Resampler) Free() {
+	if r.h != nil {
+		r.h.Close()
+		r.h = nil
+	}
+}
+
+//Close frees the resampler, like Free().
+func (r *Resampler) Close() error {
+	r.Free()
+	return nil
+}
@@ -10,17 +10,22 @@ package swresample
 	#include <libswresample/swresample.h>
 */
 import "C"
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
 
 type (
 	Context        C.struct_SwrContext
-	Frame          C.struct_AVFrame
-	Class          C.struct_AVClass
-	AvSampleFormat C.enum_AVSampleFormat
+	Frame          = avutil.Frame
+	Class          = avutil.Class
+	AvSampleFormat = avutil.AvSampleFormat
 )
 
 //Get the Class for Context.
 func SwrGetClass() *Class {
-	return (*Class)(C.swr_get_class())
+	return (*Class)(unsafe.Pointer(C.swr_get_class()))
 }
 
 //Context constructor functions.Allocate Context.
@@ -0,0 +1,137 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swresample
+
+import (
+	"time"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//Synchronizer keeps the audio output of a Resampler aligned with the timestamps of its input frames.
+//Small drifts are absorbed by stretching or squeezing the output ("soft" compensation),
+//large ones by inserting silence or dropping output samples.
+//Output frames are timestamped in 1/output sample rate.
+type Synchronizer struct {
+	r *Resampler
+
+	//Drift below which no compensation is applied.
+	MinCompensation time.Duration
+	//Drift above which samples are inserted or dropped instead of being compensated softly.
+	MinHardCompensation time.Duration
+	//Maximum ratio by which the output is stretched or squeezed during soft compensation.
+	MaxSoftCompensation float64
+	//Duration over which a soft compensation is spread.
+	CompensationDuration time.Duration
+
+	nextPts   int64
+	lastDrift time.Duration
+	//Output samples requested to be dropped that the resampler has not dropped yet.
+	pendingDrop int64
+}
+
+//Allocate a Synchronizer for r with defaults suitable for live ingest:
+//drifts under 1ms are ignored, drifts over 100ms are corrected at once
+//and the output is never stretched by more than 0.5%.
+func NewSynchronizer(r *Resampler) *Synchronizer {
+	return &Synchronizer{
+		r:                    r,
+		MinCompensation:      time.Millisecond,
+		MinHardCompensation:  100 * time.Millisecond,
+		MaxSoftCompensation:  0.005,
+		CompensationDuration: time.Second,
+		nextPts:              avutil.AV_NOPTS_VALUE,
+	}
+}
+
+//Return the drift measured on the last frame, positive when the input was ahead of the output.
+func (s *Synchronizer) LastDrift() time.Duration {
+	return s.lastDrift
+}
+
+//Compensate the drift between the pts of in, expressed in tb, and the position of the output, then convert in.
+//The returned frame must be freed with avutil.AvFrameFree().
+func (s *Synchronizer) Convert(in *avutil.Frame, tb avutil.Rational) (*avutil.Frame, error) {
+	if in.Pts() != avutil.AV_NOPTS_VALUE {
+		if err := s.compensate(avutil.AvRescaleQ(in.Pts(), tb, avutil.NewRational(1, s.r.out.SampleRate))); err != nil {
+			return nil, err
+		}
+	}
+	out, err := s.r.Convert(in)
+	if err != nil {
+		return nil, err
+	}
+	if s.pendingDrop > 0 {
+		// Samples that went in without coming out were dropped.
+		expected := int64(in.NbSamples()) * int64(s.r.out.SampleRate) / int64(s.r.in.SampleRate)
+		if missing := expected - int64(out.NbSamples()); missing > 0 {
+			if missing > s.pendingDrop {
+				missing = s.pendingDrop
+			}
+			s.pendingDrop -= missing
+		}
+	}
+	s.stamp(out)
+	return out, nil
+}
+
+//Drain the samples buffered by the resampler at the end of the stream, see Resampler.Flush().
+func (s *Synchronizer) Flush() (*avutil.Frame, error) {
+	out, err := s.r.Flush()
+	if out != nil {
+		s.stamp(out)
+	}
+	return out, err
+}
+
+func (s *Synchronizer) stamp(out *avutil.Frame) {
+	if s.nextPts == avutil.AV_NOPTS_VALUE {
+		s.nextPts = 0
+	}
+	out.SetPts(s.nextPts)
+	s.nextPts += int64(out.NbSamples())
+}
+
+//Apply a compensation for an input frame expected at pts, in output samples.
+func (s *Synchronizer) compensate(pts int64) error {
+	if s.nextPts == avutil.AV_NOPTS_VALUE {
+		s.nextPts = pts
+	}
+	rate := int64(s.r.out.SampleRate)
+	drift := pts - (s.nextPts + s.r.Delay() - s.pendingDrop)
+	s.lastDrift = time.Duration(drift * int64(time.Second) / rate)
+
+	abs := s.lastDrift
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < s.MinCompensation:
+		return nil
+	case abs >= s.MinHardCompensation:
+		if err := avutil.NewError(s.r.Context().SwrSetCompensation(0, 0)); err != nil {
+			return err
+		}
+		if drift > 0 {
+			return avutil.NewError(s.r.Context().SwrInjectSilence(int(drift * int64(s.r.in.SampleRate) / rate)))
+		}
+		if err := avutil.NewError(s.r.Context().SwrDropOutput(int(-drift))); err != nil {
+			return err
+		}
+		s.pendingDrop += -drift
+		return nil
+	default:
+		duration := int64(s.CompensationDuration.Seconds() * float64(rate))
+		if duration <= 0 {
+			duration = 1
+		}
+		max := int64(s.MaxSoftCompensation * float64(duration))
+		if drift > max {
+			drift = max
+		} else if drift < -max {
+			drift = -max
+		}
+		return avutil.NewError(s.r.Context().SwrSetCompensation(int(drift), int(duration)))
+	}
+}
@@ -0,0 +1,158 @@
+package swresample
+
+import (
+	"math"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+const (
+	testSampleRate = 48000
+	testFrameSize  = 1024
+)
+
+var testFormat = AudioFormat{
+	ChannelLayout: avutil.AV_CH_LAYOUT_STEREO,
+	SampleFormat:  avutil.AV_SAMPLE_FMT_FLT,
+	SampleRate:    testSampleRate,
+}
+
+//Return a frame of testFrameSize samples of a 440Hz sine wave starting at sample pts, timestamped with pts.
+func newSineFrame(t *testing.T, pts int64) *avutil.Frame {
+	f := avutil.AvFrameAlloc()
+	if f == nil {
+		t.Fatal("allocating frame failed")
+	}
+	f.SetChannelLayout(testFormat.ChannelLayout)
+	f.SetChannels(2)
+	f.SetFormat(int(testFormat.SampleFormat))
+	f.SetSampleRate(testFormat.SampleRate)
+	f.SetNbSamples(testFrameSize)
+	if err := avutil.NewError(avutil.AvFrameGetBuffer(f, 0)); err != nil {
+		avutil.AvFrameFree(f)
+		t.Fatal(err)
+	}
+	samples := (*[2 * testFrameSize]float32)(unsafe.Pointer(f.DataAt(0)))
+	for i := 0; i < testFrameSize; i++ {
+		v := float32(math.Sin(2 * math.Pi * 440 * float64(pts+int64(i)) / testSampleRate))
+		samples[2*i], samples[2*i+1] = v, v
+	}
+	f.SetPts(pts)
+	return f
+}
+
+func newTestSynchronizer(t *testing.T) *Synchronizer {
+	r, err := NewResampler(testFormat, testFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewSynchronizer(r)
+}
+
+//Convert a frame starting at pts and return the number of output samples.
+func convertSine(t *testing.T, s *Synchronizer, pts int64) int {
+	in := newSineFrame(t, pts)
+	defer avutil.AvFrameFree(in)
+	out, err := s.Convert(in, avutil.NewRational(1, testSampleRate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer avutil.AvFrameFree(out)
+	return out.NbSamples()
+}
+
+func durationToSamples(d time.Duration) int64 {
+	return int64(d) * testSampleRate / int64(time.Second)
+}
+
+func TestSynchronizerSoftCompensation(t *testing.T) {
+	s := newTestSynchronizer(t)
+	defer s.r.Close()
+
+	// The input jumps 10ms ahead after a few frames: more than MinCompensation, less than MinHardCompensation.
+	skew := durationToSamples(10 * time.Millisecond)
+	var pts int64
+	var in, out int64
+	for i := 0; i < 100; i++ {
+		if i == 5 {
+			pts += skew
+		}
+		out += int64(convertSine(t, s, pts))
+		in += testFrameSize
+		if i == 5 {
+			if d := s.LastDrift(); d < 9*time.Millisecond || d > 11*time.Millisecond {
+				t.Fatalf("drift after skew is %v, expected about 10ms", d)
+			}
+		}
+		pts += testFrameSize
+	}
+
+	// The output is stretched to absorb the skew, without inserting it at once.
+	total := out + s.r.Delay()
+	if total <= in {
+		t.Fatalf("output has %d samples, expected more than the %d input samples", total, in)
+	}
+	if max := in + skew + testFrameSize; total > max {
+		t.Fatalf("output has %d samples, expected at most %d", total, max)
+	}
+	if d := s.LastDrift(); d >= 10*time.Millisecond || d <= -10*time.Millisecond {
+		t.Fatalf("drift is still %v", d)
+	}
+}
+
+func TestSynchronizerHardInject(t *testing.T) {
+	s := newTestSynchronizer(t)
+	defer s.r.Close()
+
+	skew := durationToSamples(200 * time.Millisecond)
+	convertSine(t, s, 0)
+	n := convertSine(t, s, testFrameSize+skew)
+	if d := s.LastDrift(); d < 199*time.Millisecond || d > 201*time.Millisecond {
+		t.Fatalf("drift is %v, expected about 200ms", d)
+	}
+	// Silence is inserted before the samples of the frame.
+	if int64(n)+s.r.Delay() < skew+testFrameSize {
+		t.Fatalf("output has %d samples, expected at least %d", int64(n)+s.r.Delay(), skew+testFrameSize)
+	}
+
+	// The output is aligned again.
+	convertSine(t, s, 2*testFrameSize+skew)
+	if d := s.LastDrift(); d > s.MinCompensation || d < -s.MinCompensation {
+		t.Fatalf("drift after injection is %v", d)
+	}
+}
+
+func TestSynchronizerHardDrop(t *testing.T) {
+	s := newTestSynchronizer(t)
+	defer s.r.Close()
+
+	skew := durationToSamples(200 * time.Millisecond)
+	pts := int64(10 * testFrameSize)
+	for i := 0; i < 10; i++ {
+		convertSine(t, s, int64(i)*testFrameSize)
+	}
+
+	// The input jumps 200ms back: the output drops as many samples, once.
+	pts -= skew
+	var in, out int64
+	for i := 0; i < 20; i++ {
+		out += int64(convertSine(t, s, pts))
+		in += testFrameSize
+		if i == 0 {
+			if d := s.LastDrift(); d > -199*time.Millisecond || d < -201*time.Millisecond {
+				t.Fatalf("drift is %v, expected about -200ms", d)
+			}
+		}
+		pts += testFrameSize
+	}
+	total := out + s.r.Delay()
+	if expected := in - skew; total < expected-testFrameSize || total > expected+testFrameSize {
+		t.Fatalf("output has %d samples, expected about %d", total, expected)
+	}
+	if d := s.LastDrift(); d > s.MinCompensation || d < -s.MinCompensation {
+		t.Fatalf("drift after drop is %v", d)
+	}
+}
@@ -0,0 +1,157 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swscale
+
+//#cgo pkg-config: libswscale libavutil
+//#include <libswscale/swscale.h>
+//#include <libavutil/pixfmt.h>
+import "C"
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//Colorspace selects the YUV<->RGB coefficients used by swscale.
+type Colorspace int
+
+const (
+	SWS_CS_ITU709    Colorspace = C.SWS_CS_ITU709
+	SWS_CS_FCC       Colorspace = C.SWS_CS_FCC
+	SWS_CS_ITU601    Colorspace = C.SWS_CS_ITU601
+	SWS_CS_ITU624    Colorspace = C.SWS_CS_ITU624
+	SWS_CS_SMPTE170M Colorspace = C.SWS_CS_SMPTE170M
+	SWS_CS_SMPTE240M Colorspace = C.SWS_CS_SMPTE240M
+	SWS_CS_DEFAULT   Colorspace = C.SWS_CS_DEFAULT
+	SWS_CS_BT2020    Colorspace = C.SWS_CS_BT2020
+)
+
+//Colorspaces that can be recognized from the coefficient tables returned by swscale.
+var knownColorspaces = []Colorspace{SWS_CS_ITU601, SWS_CS_ITU709, SWS_CS_FCC, SWS_CS_SMPTE240M, SWS_CS_BT2020}
+
+var ErrColorspaceNotSupported = errors.New("swscale: colorspace details are not supported by this context")
+
+//ColorspaceDetails describes the colorspace conversion performed by a Context.
+//Brightness, Contrast and Saturation are converted to swscale's 16.16 fixed point values:
+//0, 1 and 1 leave the picture unchanged.
+type ColorspaceDetails struct {
+	SrcColorspace Colorspace
+	SrcFullRange  bool
+	DstColorspace Colorspace
+	DstFullRange  bool
+	Brightness    float64
+	Contrast      float64
+	Saturation    float64
+}
+
+//Return the details swscale uses when none are set.
+func DefaultColorspaceDetails() ColorspaceDetails {
+	return ColorspaceDetails{
+		SrcColorspace: SWS_CS_DEFAULT,
+		DstColorspace: SWS_CS_DEFAULT,
+		Contrast:      1,
+		Saturation:    1,
+	}
+}
+
+//Return the yuv<->rgb coefficients for the given colorspace.
+func Coefficients(cs Colorspace) [4]int {
+	t := (*[4]C.int)(unsafe.Pointer(C.sws_getCoefficients(C.int(cs))))
+	return [4]int{int(t[0]), int(t[1]), int(t[2]), int(t[3])}
+}
+
+//Set the colorspace conversion details of the context.
+func (ctxt *Context) SetColorspaceDetails(d ColorspaceDetails) error {
+	ret := C.sws_setColorspaceDetails((*C.struct_SwsContext)(ctxt),
+		C.sws_getCoefficients(C.int(d.SrcColorspace)), boolToCInt(d.SrcFullRange),
+		C.sws_getCoefficients(C.int(d.DstColorspace)), boolToCInt(d.DstFullRange),
+		toFixed16(d.Brightness), toFixed16(d.Contrast), toFixed16(d.Saturation))
+	if ret < 0 {
+		return ErrColorspaceNotSupported
+	}
+	return nil
+}
+
+//Return the colorspace conversion details of the context.
+//Coefficient tables that do not match a known colorspace are reported as SWS_CS_DEFAULT.
+func (ctxt *Context) ColorspaceDetails() (ColorspaceDetails, error) {
+	var it, t *C.int
+	var sr, dr, b, c, s C.int
+	if C.sws_getColorspaceDetails((*C.struct_SwsContext)(ctxt), &it, &sr, &t, &dr, &b, &c, &s) < 0 {
+		return ColorspaceDetails{}, ErrColorspaceNotSupported
+	}
+	return ColorspaceDetails{
+		SrcColorspace: colorspaceFromTable(it),
+		SrcFullRange:  sr != 0,
+		DstColorspace: colorspaceFromTable(t),
+		DstFullRange:  dr != 0,
+		Brightness:    fromFixed16(b),
+		Contrast:      fromFixed16(c),
+		Saturation:    fromFixed16(s),
+	}, nil
+}
+
+//Return the swscale colorspace and range matching the color properties of f.
+//When the frame colorspace is unspecified, BT.709 is assumed for HD content (height >= 720) and BT.601 otherwise.
+func ColorspaceFromFrame(f *avutil.Frame) (cs Colorspace, fullRange bool) {
+	switch f.Colorspace() {
+	case avutil.AVCOL_SPC_BT709:
+		cs = SWS_CS_ITU709
+	case avutil.AVCOL_SPC_FCC:
+		cs = SWS_CS_FCC
+	case avutil.AVCOL_SPC_BT470BG, avutil.AVCOL_SPC_SMPTE170M:
+		cs = SWS_CS_ITU601
+	case avutil.AVCOL_SPC_SMPTE240M:
+		cs = SWS_CS_SMPTE240M
+	case avutil.AVCOL_SPC_BT2020_NCL, avutil.AVCOL_SPC_BT2020_CL:
+		cs = SWS_CS_BT2020
+	default:
+		if f.Height() >= 720 {
+			cs = SWS_CS_ITU709
+		} else {
+			cs = SWS_CS_ITU601
+		}
+	}
+
+	switch f.ColorRange() {
+	case avutil.AVCOL_RANGE_JPEG:
+		fullRange = true
+	case avutil.AVCOL_RANGE_UNSPECIFIED:
+		fullRange = isJpegPixelFormat(avutil.PixelFormat(f.Format()))
+	}
+	return
+}
+
+func isJpegPixelFormat(p avutil.PixelFormat) bool {
+	switch p {
+	case C.AV_PIX_FMT_YUVJ420P, C.AV_PIX_FMT_YUVJ411P, C.AV_PIX_FMT_YUVJ422P, C.AV_PIX_FMT_YUVJ440P, C.AV_PIX_FMT_YUVJ444P:
+		return true
+	}
+	return false
+}
+
+func colorspaceFromTable(t *C.int) Colorspace {
+	for _, cs := range knownColorspaces {
+		if C.sws_getCoefficients(C.int(cs)) == t {
+			return cs
+		}
+	}
+	return SWS_CS_DEFAULT
+}
+
+func boolToCInt(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func toFixed16(f float64) C.int {
+	return C.int(f * (1 << 16))
+}
+
+func fromFixed16(i C.int) float64 {
+	return float64(i) / (1 << 16)
+}
@@ -25,6 +25,7 @@ func SwsInitContext(ctxt *Context, sf, df *Filter) int {
 //Free the swscaler context swsContext.
 func SwsFreecontext(ctxt *Context) {
 	C.sws_freeContext((*C.struct_SwsContext)(ctxt))
+	avutil.UnregisterLogContext(unsafe.Pointer(ctxt))
 }
 
 //Allocate and return an Context.
@@ -0,0 +1,70 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swscale
+
+//#cgo pkg-config: libswscale libavutil
+//#include <libswscale/swscale.h>
+//#include <libavutil/mem.h>
+import "C"
+import (
+	"unsafe"
+)
+
+//Allocate a Filter made of the given luma and chroma vectors, any of which may be nil.
+//The filter takes ownership of the vectors, which are freed by SwsFreefilter(), so they must not be shared.
+func NewFilter(lumH, lumV, chrH, chrV *Vector) *Filter {
+	f := (*Filter)(C.av_mallocz(C.size_t(unsafe.Sizeof(C.struct_SwsFilter{}))))
+	if f == nil {
+		return nil
+	}
+	f.SetLumH(lumH)
+	f.SetLumV(lumV)
+	f.SetChrH(chrH)
+	f.SetChrV(chrV)
+	return f
+}
+
+//Allocate a Filter applying the luma kernel horizontally and vertically on luma planes
+//and the chroma kernel horizontally and vertically on chroma planes. A nil kernel leaves the planes unfiltered.
+func NewFilterFromKernels(luma, chroma []float64) *Filter {
+	vec := func(k []float64) *Vector {
+		if len(k) == 0 {
+			return nil
+		}
+		return NewVector(k)
+	}
+	return NewFilter(vec(luma), vec(luma), vec(chroma), vec(chroma))
+}
+
+func (f *Filter) LumH() *Vector {
+	return (*Vector)(f.lumH)
+}
+
+func (f *Filter) SetLumH(v *Vector) {
+	f.lumH = (*C.struct_SwsVector)(v)
+}
+
+func (f *Filter) LumV() *Vector {
+	return (*Vector)(f.lumV)
+}
+
+func (f *Filter) SetLumV(v *Vector) {
+	f.lumV = (*C.struct_SwsVector)(v)
+}
+
+func (f *Filter) ChrH() *Vector {
+	return (*Vector)(f.chrH)
+}
+
+func (f *Filter) SetChrH(v *Vector) {
+	f.chrH = (*C.struct_SwsVector)(v)
+}
+
+func (f *Filter) ChrV() *Vector {
+	return (*Vector)(f.chrV)
+}
+
+func (f *Filter) SetChrV(v *Vector) {
+	f.chrV = (*C.struct_SwsVector)(v)
+}
@@ -0,0 +1,102 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swscale
+
+//#cgo pkg-config: libswscale libavutil
+//#include <errno.h>
+//#include <libswscale/swscale.h>
+//#include <libavutil/error.h>
+//#include <libavutil/frame.h>
+/*
+// The frame based API was added in libswscale 6.1.100 (FFmpeg 5.0)
+#if LIBSWSCALE_VERSION_INT >= AV_VERSION_INT(6, 1, 100)
+static int goavSwsScaleFrame(struct SwsContext *c, AVFrame *dst, const AVFrame *src)
+{
+	return sws_scale_frame(c, dst, src);
+}
+static int goavSwsFrameStart(struct SwsContext *c, AVFrame *dst, const AVFrame *src)
+{
+	return sws_frame_start(c, dst, src);
+}
+static void goavSwsFrameEnd(struct SwsContext *c)
+{
+	sws_frame_end(c);
+}
+static int goavSwsSendSlice(struct SwsContext *c, unsigned int y, unsigned int h)
+{
+	return sws_send_slice(c, y, h);
+}
+static int goavSwsReceiveSlice(struct SwsContext *c, unsigned int y, unsigned int h)
+{
+	return sws_receive_slice(c, y, h);
+}
+static unsigned int goavSwsReceiveSliceAlignment(const struct SwsContext *c)
+{
+	return sws_receive_slice_alignment(c);
+}
+#else
+static int goavSwsScaleFrame(struct SwsContext *c, AVFrame *dst, const AVFrame *src)
+{
+	return AVERROR(ENOSYS);
+}
+static int goavSwsFrameStart(struct SwsContext *c, AVFrame *dst, const AVFrame *src)
+{
+	return AVERROR(ENOSYS);
+}
+static void goavSwsFrameEnd(struct SwsContext *c)
+{
+}
+static int goavSwsSendSlice(struct SwsContext *c, unsigned int y, unsigned int h)
+{
+	return AVERROR(ENOSYS);
+}
+static int goavSwsReceiveSlice(struct SwsContext *c, unsigned int y, unsigned int h)
+{
+	return AVERROR(ENOSYS);
+}
+static unsigned int goavSwsReceiveSliceAlignment(const struct SwsContext *c)
+{
+	return 0;
+}
+#endif
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//Scale source data from src and write the output to dst.
+//If dst has no buffers, they are allocated by swscale. Requires FFmpeg 5.0 or later, AVERROR(ENOSYS) is returned otherwise.
+func SwsScaleFrame(ctxt *Context, dst, src *avutil.Frame) int {
+	return int(C.goavSwsScaleFrame((*C.struct_SwsContext)(ctxt), (*C.struct_AVFrame)(unsafe.Pointer(dst)), (*C.struct_AVFrame)(unsafe.Pointer(src))))
+}
+
+//Initialize the scaling process for a given pair of source/destination frames.
+//Slices are then submitted with SwsSendSlice() and output with SwsReceiveSlice().
+func SwsFrameStart(ctxt *Context, dst, src *avutil.Frame) int {
+	return int(C.goavSwsFrameStart((*C.struct_SwsContext)(ctxt), (*C.struct_AVFrame)(unsafe.Pointer(dst)), (*C.struct_AVFrame)(unsafe.Pointer(src))))
+}
+
+//Finish the scaling process for a pair of source/destination frames previously submitted with SwsFrameStart().
+func SwsFrameEnd(ctxt *Context) {
+	C.goavSwsFrameEnd((*C.struct_SwsContext)(ctxt))
+}
+
+//Indicate that a horizontal slice of input data is available in the source frame.
+func SwsSendSlice(ctxt *Context, y, h uint) int {
+	return int(C.goavSwsSendSlice((*C.struct_SwsContext)(ctxt), C.uint(y), C.uint(h)))
+}
+
+//Request a horizontal slice of the output data to be written into the destination frame.
+//Returns AVERROR_EAGAIN if more input is needed to produce the slice.
+func SwsReceiveSlice(ctxt *Context, y, h uint) int {
+	return int(C.goavSwsReceiveSlice((*C.struct_SwsContext)(ctxt), C.uint(y), C.uint(h)))
+}
+
+//Return the alignment required for slices passed to SwsReceiveSlice(), or 0 if the frame based API is not available.
+func SwsReceiveSliceAlignment(ctxt *Context) uint {
+	return uint(C.goavSwsReceiveSliceAlignment((*C.struct_SwsContext)(ctxt)))
+}
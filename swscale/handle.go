@@ -0,0 +1,42 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swscale
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//ContextHandle owns a Context allocated by NewContext().
+type ContextHandle struct {
+	*avutil.Handle
+}
+
+//Allocate a Context converting images from the source size and pixel format to the destination ones,
+//owned by the returned handle, which must be closed.
+func NewContext(srcW, srcH int, srcFmt avutil.PixelFormat, dstW, dstH int, dstFmt avutil.PixelFormat, flags Flags) (*ContextHandle, error) {
+	ctx := SwsGetcontext(srcW, srcH, srcFmt, dstW, dstH, dstFmt, int(flags), nil, nil, nil)
+	if ctx == nil {
+		return nil, errors.New("swscale: allocating context failed")
+	}
+	return newContextHandle(ctx), nil
+}
+
+func newContextHandle(ctx *Context) *ContextHandle {
+	return &ContextHandle{avutil.NewHandle("swscale.Context", unsafe.Pointer(ctx), func(p unsafe.Pointer) {
+		SwsFreecontext((*Context)(p))
+	})}
+}
+
+//Return the context owned by the handle, or nil once it is closed or released.
+func (c *ContextHandle) Context() *Context {
+	return (*Context)(c.Pointer())
+}
+
+//Give up the ownership of the context, which must then be freed with SwsFreecontext().
+func (c *ContextHandle) Release() *Context {
+	return (*Context)(c.Handle.Release())
+}
@@ -0,0 +1,209 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swscale
+
+//#cgo pkg-config: libswscale libavutil
+//#include <libswscale/swscale.h>
+//#include <libavutil/frame.h>
+//#include <libavutil/pixdesc.h>
+/*
+static int goavChromaShift(enum AVPixelFormat f)
+{
+	const AVPixFmtDescriptor *d = av_pix_fmt_desc_get(f);
+	return d ? d->log2_chroma_h : 0;
+}
+
+// Fill data and linesize with the planes of f starting at row y.
+// Planes that hold no component (e.g. palettes) are left untouched.
+static void goavBandPlanes(const AVFrame *f, int y, uint8_t *data[4], int linesize[4])
+{
+	const AVPixFmtDescriptor *d = av_pix_fmt_desc_get(f->format);
+	int i, c;
+	for (i = 0; i < 4; i++) {
+		int offset = 0, shift = 0;
+		data[i] = f->data[i];
+		linesize[i] = f->linesize[i];
+		if (!d || !data[i])
+			continue;
+		for (c = 0; c < d->nb_components; c++) {
+			if (d->comp[c].plane != i)
+				continue;
+			offset = 1;
+			if ((c == 1 || c == 2) && !(d->flags & AV_PIX_FMT_FLAG_RGB))
+				shift = d->log2_chroma_h;
+		}
+		if (offset)
+			data[i] += (y >> shift) * linesize[i];
+	}
+}
+
+static int goavScaleBand(struct SwsContext *c, const AVFrame *src, int srcY, int srcH, AVFrame *dst, int dstY)
+{
+	uint8_t *srcData[4], *dstData[4];
+	int srcLinesize[4], dstLinesize[4];
+	goavBandPlanes(src, srcY, srcData, srcLinesize);
+	goavBandPlanes(dst, dstY, dstData, dstLinesize);
+	return sws_scale(c, (const uint8_t * const *)srcData, srcLinesize, 0, srcH, dstData, dstLinesize);
+}
+*/
+import "C"
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//ParallelScaler scales frames by splitting them into horizontal bands that are scaled concurrently,
+//each band by its own Context. Vertical filter taps do not cross band boundaries, so some filters
+//and scaling ratios can leave faint seams between bands.
+type ParallelScaler struct {
+	workers int
+	srcW    int
+	srcH    int
+	srcFmt  avutil.PixelFormat
+	dstW    int
+	dstH    int
+	dstFmt  avutil.PixelFormat
+	flags   Flags
+	details *ColorspaceDetails
+	bands   []*scalerBand
+}
+
+type scalerBand struct {
+	*Scaler
+	srcY int
+	dstY int
+}
+
+//Allocate a ParallelScaler using at most workers bands. If workers <= 0, the number of CPUs is used.
+func NewParallelScaler(srcW, srcH int, srcFmt avutil.PixelFormat, dstW, dstH int, dstFmt avutil.PixelFormat, flags Flags, workers int) (*ParallelScaler, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	s := &ParallelScaler{
+		workers: workers,
+		srcW:    srcW,
+		srcH:    srcH,
+		srcFmt:  srcFmt,
+		dstW:    dstW,
+		dstH:    dstH,
+		dstFmt:  dstFmt,
+		flags:   flags,
+	}
+	if err := s.update(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+//Split the frame into bands whose boundaries are aligned on the chroma subsampling of both formats.
+func (s *ParallelScaler) update() error {
+	s.freeBands()
+	if s.srcH <= 0 || s.dstH <= 0 {
+		return errors.New("swscale: invalid frame height")
+	}
+
+	srcAlign := 1 << uint(C.goavChromaShift(C.enum_AVPixelFormat(s.srcFmt)))
+	dstAlign := 1 << uint(C.goavChromaShift(C.enum_AVPixelFormat(s.dstFmt)))
+	bounds := [][2]int{{0, 0}}
+	for i := 1; i < s.workers; i++ {
+		dy := i * s.dstH / s.workers / dstAlign * dstAlign
+		sy := int(int64(dy)*int64(s.srcH)/int64(s.dstH)) / srcAlign * srcAlign
+		last := bounds[len(bounds)-1]
+		if sy > last[0] && dy > last[1] && sy < s.srcH && dy < s.dstH {
+			bounds = append(bounds, [2]int{sy, dy})
+		}
+	}
+	bounds = append(bounds, [2]int{s.srcH, s.dstH})
+
+	for i := 0; i < len(bounds)-1; i++ {
+		sc, err := NewScaler(s.srcW, bounds[i+1][0]-bounds[i][0], s.srcFmt, s.dstW, bounds[i+1][1]-bounds[i][1], s.dstFmt, s.flags)
+		if err != nil {
+			s.freeBands()
+			return err
+		}
+		s.bands = append(s.bands, &scalerBand{Scaler: sc, srcY: bounds[i][0], dstY: bounds[i][1]})
+		if s.details != nil {
+			if err := sc.SetColorspaceDetails(*s.details); err != nil {
+				s.freeBands()
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//Return the number of bands frames are split into.
+func (s *ParallelScaler) NbBands() int {
+	return len(s.bands)
+}
+
+//Set the colorspace conversion details of every band.
+func (s *ParallelScaler) SetColorspaceDetails(d ColorspaceDetails) error {
+	s.details = &d
+	for _, b := range s.bands {
+		if err := b.SetColorspaceDetails(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Scale src into dst, one goroutine per band. If dst has no buffer, it is allocated with the destination size and pixel format.
+//The bands are rebuilt when the source size or pixel format differs from the previous call.
+func (s *ParallelScaler) ScaleFrame(dst, src *avutil.Frame) error {
+	if src.Width() != s.srcW || src.Height() != s.srcH || avutil.PixelFormat(src.Format()) != s.srcFmt {
+		s.srcW, s.srcH, s.srcFmt = src.Width(), src.Height(), avutil.PixelFormat(src.Format())
+		if err := s.update(); err != nil {
+			return err
+		}
+	}
+	if len(s.bands) == 0 {
+		return errors.New("swscale: parallel scaler is not initialized")
+	}
+
+	if err := prepareDstFrame(dst, src, s.dstW, s.dstH, s.dstFmt); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(s.bands))
+	var wg sync.WaitGroup
+	for i, b := range s.bands {
+		wg.Add(1)
+		go func(i int, b *scalerBand) {
+			defer wg.Done()
+			ret := C.goavScaleBand((*C.struct_SwsContext)(b.Context()), (*C.struct_AVFrame)(unsafe.Pointer(src)), C.int(b.srcY), C.int(b.srcH), (*C.struct_AVFrame)(unsafe.Pointer(dst)), C.int(b.dstY))
+			errs[i] = avutil.NewError(int(ret))
+		}(i, b)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ParallelScaler) freeBands() {
+	for _, b := range s.bands {
+		b.Free()
+	}
+	s.bands = nil
+}
+
+//Free the contexts of all bands.
+func (s *ParallelScaler) Free() {
+	s.freeBands()
+}
+
+//Close frees the scaler, like Free().
+func (s *ParallelScaler) Close() error {
+	s.Free()
+	return nil
+}
@@ -0,0 +1,65 @@
+package swscale
+
+import (
+	"testing"
+
+	"github.com/asticode/goav/avutil"
+)
+
+const (
+	benchSrcW = 1920
+	benchSrcH = 1080
+	benchDstW = 1280
+	benchDstH = 720
+)
+
+type frameScaler interface {
+	ScaleFrame(dst, src *avutil.Frame) error
+	Close() error
+}
+
+func newBenchFrame(b *testing.B, w, h int, f avutil.PixelFormat) *avutil.Frame {
+	fr := avutil.AvFrameAlloc()
+	if fr == nil {
+		b.Fatal("frame allocation failed")
+	}
+	fr.SetWidth(w)
+	fr.SetHeight(h)
+	fr.SetFormat(int(f))
+	if err := avutil.NewError(avutil.AvFrameGetBuffer(fr, 0)); err != nil {
+		avutil.AvFrameFree(fr)
+		b.Fatal(err)
+	}
+	return fr
+}
+
+func benchmarkScaler(b *testing.B, s frameScaler) {
+	defer s.Close()
+	src := newBenchFrame(b, benchSrcW, benchSrcH, avutil.AV_PIX_FMT_YUV420P)
+	defer avutil.AvFrameFree(src)
+	dst := newBenchFrame(b, benchDstW, benchDstH, avutil.AV_PIX_FMT_RGBA)
+	defer avutil.AvFrameFree(dst)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := s.ScaleFrame(dst, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScaler(b *testing.B) {
+	s, err := NewScaler(benchSrcW, benchSrcH, avutil.AV_PIX_FMT_YUV420P, benchDstW, benchDstH, avutil.AV_PIX_FMT_RGBA, SWS_BICUBIC)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkScaler(b, s)
+}
+
+func BenchmarkParallelScaler(b *testing.B) {
+	s, err := NewParallelScaler(benchSrcW, benchSrcH, avutil.AV_PIX_FMT_YUV420P, benchDstW, benchDstH, avutil.AV_PIX_FMT_RGBA, SWS_BICUBIC, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkScaler(b, s)
+}
@@ -0,0 +1,175 @@
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// Giorgis (habtom@giorgis.io)
+
+package swscale
+
+//#cgo pkg-config: libswscale libavutil
+//#include <libswscale/swscale.h>
+//#include <libavutil/frame.h>
+/*
+static int goavScaleFrame(struct SwsContext *c, const AVFrame *src, AVFrame *dst)
+{
+	return sws_scale(c, (const uint8_t * const *)src->data, src->linesize, 0, src->height, dst->data, dst->linesize);
+}
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/asticode/goav/avutil"
+)
+
+//Scaler converts frames from one size and pixel format to another.
+//The underlying Context is reused as long as the source parameters do not change.
+type Scaler struct {
+	h      *ContextHandle
+	srcW   int
+	srcH   int
+	srcFmt avutil.PixelFormat
+	dstW   int
+	dstH   int
+	dstFmt avutil.PixelFormat
+	flags  Flags
+
+	srcFilter      *Filter
+	dstFilter      *Filter
+	details        *ColorspaceDetails
+	autoColorspace bool
+}
+
+//Allocate a Scaler converting srcW x srcH images in srcFmt into dstW x dstH images in dstFmt.
+func NewScaler(srcW, srcH int, srcFmt avutil.PixelFormat, dstW, dstH int, dstFmt avutil.PixelFormat, flags Flags) (*Scaler, error) {
+	s := &Scaler{
+		srcW:   srcW,
+		srcH:   srcH,
+		srcFmt: srcFmt,
+		dstW:   dstW,
+		dstH:   dstH,
+		dstFmt: dstFmt,
+		flags:  flags,
+	}
+	if err := s.update(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Scaler) update() error {
+	// sws_getCachedContext() frees the previous context when it cannot reuse it.
+	var prev *Context
+	if s.h != nil {
+		prev = s.h.Release()
+		s.h = nil
+	}
+	ctx := SwsGetcachedcontext(prev, s.srcW, s.srcH, s.srcFmt, s.dstW, s.dstH, s.dstFmt, int(s.flags), s.srcFilter, s.dstFilter, nil)
+	if ctx == nil {
+		return fmt.Errorf("swscale: unsupported conversion %dx%d %s -> %dx%d %s", s.srcW, s.srcH, avutil.AvGetPixFmtName(s.srcFmt), s.dstW, s.dstH, avutil.AvGetPixFmtName(s.dstFmt))
+	}
+	s.h = newContextHandle(ctx)
+	if s.details != nil {
+		return ctx.SetColorspaceDetails(*s.details)
+	}
+	return nil
+}
+
+//Set the filters applied to the source and destination images, either of which may be nil.
+//The filters are not owned by the scaler and must outlive it.
+func (s *Scaler) SetFilters(src, dst *Filter) error {
+	s.srcFilter, s.dstFilter = src, dst
+	// sws_getCachedContext() does not compare filters, so the context is always recreated
+	s.Free()
+	return s.update()
+}
+
+//Set the colorspace conversion details. They are kept when the context is reconfigured.
+func (s *Scaler) SetColorspaceDetails(d ColorspaceDetails) error {
+	s.details = &d
+	ctx := s.Context()
+	if ctx == nil {
+		return nil
+	}
+	return ctx.SetColorspaceDetails(d)
+}
+
+//Enable or disable the selection of the source colorspace and range from the color properties of each source frame.
+func (s *Scaler) SetAutoColorspace(a bool) {
+	s.autoColorspace = a
+}
+
+func (s *Scaler) applyFrameColorspace(f *avutil.Frame) error {
+	d := DefaultColorspaceDetails()
+	if s.details != nil {
+		d = *s.details
+	}
+	d.SrcColorspace, d.SrcFullRange = ColorspaceFromFrame(f)
+	if s.details != nil && d == *s.details {
+		return nil
+	}
+	return s.SetColorspaceDetails(d)
+}
+
+//Return the underlying Context, or nil once the scaler is freed.
+func (s *Scaler) Context() *Context {
+	if s.h == nil {
+		return nil
+	}
+	return s.h.Context()
+}
+
+//Scale src into dst. If dst has no buffer, it is allocated with the destination size and pixel format.
+//The context is reconfigured when the source size or pixel format differs from the previous call.
+func (s *Scaler) ScaleFrame(dst, src *avutil.Frame) error {
+	if src.Width() != s.srcW || src.Height() != s.srcH || avutil.PixelFormat(src.Format()) != s.srcFmt {
+		s.srcW, s.srcH, s.srcFmt = src.Width(), src.Height(), avutil.PixelFormat(src.Format())
+		if err := s.update(); err != nil {
+			return err
+		}
+	}
+	ctx := s.Context()
+	if ctx == nil {
+		return errors.New("swscale: scaler is not initialized")
+	}
+	if s.autoColorspace {
+		if err := s.applyFrameColorspace(src); err != nil {
+			return err
+		}
+	}
+
+	if err := prepareDstFrame(dst, src, s.dstW, s.dstH, s.dstFmt); err != nil {
+		return err
+	}
+
+	ret := int(C.goavScaleFrame((*C.struct_SwsContext)(ctx), (*C.struct_AVFrame)(unsafe.Pointer(src)), (*C.struct_AVFrame)(unsafe.Pointer(dst))))
+	return avutil.NewError(ret)
+}
+
+//Allocate the buffer of dst if needed, check that it matches the expected size and pixel format and copy the properties of src.
+func prepareDstFrame(dst, src *avutil.Frame, w, h int, f avutil.PixelFormat) error {
+	if dst.DataAt(0) == nil {
+		dst.SetWidth(w)
+		dst.SetHeight(h)
+		dst.SetFormat(int(f))
+		if err := avutil.NewError(avutil.AvFrameGetBuffer(dst, 0)); err != nil {
+			return err
+		}
+	} else if dst.Width() != w || dst.Height() != h || avutil.PixelFormat(dst.Format()) != f {
+		return fmt.Errorf("swscale: destination frame is %dx%d %s, expected %dx%d %s", dst.Width(), dst.Height(), avutil.AvGetPixFmtName(avutil.PixelFormat(dst.Format())), w, h, avutil.AvGetPixFmtName(f))
+	}
+	return avutil.NewError(avutil.AvFrameCopyProps(dst, src))
+}
+
+//Free the underlying Context.
+func (s *Scaler) Free() {
+	if s.h != nil {
+		s.h.Close()
+		s.h = nil
+	}
+}
+
+//Close frees the scaler, like Free().
+func (s *Scaler) Close() error {
+	s.Free()
+	return nil
+}
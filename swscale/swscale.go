@@ -24,9 +24,16 @@ type (
 	Context C.struct_SwsContext
 	Filter  C.struct_SwsFilter
 	Vector  C.struct_SwsVector
-	Class   C.struct_AVClass
+	Class   = avutil.Class
 )
 
+const (
+	MAX_ARRAY_SIZE = 1<<29 - 1
+)
+
+//Flags is a combination of SWS_* flags selecting the scaling algorithm and options.
+type Flags int
+
 const (
 	SWS_FAST_BILINEAR = 1
 	SWS_BILINEAR      = 2
@@ -39,6 +46,17 @@ const (
 	SWS_SINC          = 0x100
 	SWS_LANCZOS       = 0x200
 	SWS_SPLINE        = 0x400
+
+	SWS_SRC_V_CHR_DROP_MASK  = 0x30000
+	SWS_SRC_V_CHR_DROP_SHIFT = 16
+	SWS_PARAM_DEFAULT        = 123456
+	SWS_PRINT_INFO           = 0x1000
+	SWS_FULL_CHR_H_INT       = 0x2000
+	SWS_FULL_CHR_H_INP       = 0x4000
+	SWS_DIRECT_BGR           = 0x8000
+	SWS_ACCURATE_RND         = 0x40000
+	SWS_BITEXACT             = 0x80000
+	SWS_ERROR_DIFFUSION      = 0x800000
 )
 
 //Return the LIBSWSCALE_VERSION_INT constant.
@@ -122,5 +140,5 @@ func SwsConvertpalette8topacked24(s, d *uint8, px int, p *uint8) {
 
 //Get the Class for swsContext.
 func SwsGetClass() *Class {
-	return (*Class)(C.sws_get_class())
+	return (*Class)(unsafe.Pointer(C.sws_get_class()))
 }
@@ -10,6 +10,59 @@ import (
 	"unsafe"
 )
 
+//Allocate and return a vector holding a copy of the given coefficients.
+func NewVector(c []float64) *Vector {
+	v := SwsAllocvec(len(c))
+	if v == nil {
+		return nil
+	}
+	v.SetCoefficients(c)
+	return v
+}
+
+//Allocate and return a vector with length coefficients, all with the same value c.
+func ConstVector(c float64, l int) *Vector {
+	cs := make([]float64, l)
+	for i := range cs {
+		cs[i] = c
+	}
+	return NewVector(cs)
+}
+
+//Allocate and return a vector with just one coefficient, with value 1.0.
+func IdentityVector() *Vector {
+	return NewVector([]float64{1})
+}
+
+func (a *Vector) Length() int {
+	return int(a.length)
+}
+
+//Return a copy of the coefficients of the vector.
+func (a *Vector) Coefficients() []float64 {
+	l := a.Length()
+	if l == 0 || a.coeff == nil {
+		return nil
+	}
+	cs := make([]float64, l)
+	for i, c := range (*[MAX_ARRAY_SIZE]C.double)(unsafe.Pointer(a.coeff))[:l:l] {
+		cs[i] = float64(c)
+	}
+	return cs
+}
+
+//Overwrite the coefficients of the vector. Extra values in c are ignored.
+func (a *Vector) SetCoefficients(c []float64) {
+	l := a.Length()
+	if l == 0 || a.coeff == nil {
+		return
+	}
+	cs := (*[MAX_ARRAY_SIZE]C.double)(unsafe.Pointer(a.coeff))[:l:l]
+	for i := 0; i < l && i < len(c); i++ {
+		cs[i] = C.double(c[i])
+	}
+}
+
 //Allocate and return an uninitialized vector with length coefficients.
 func SwsAllocvec(l int) *Vector {
 	return (*Vector)(C.sws_allocVec(C.int(l)))